@@ -15,14 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/ai-excuse": {
-            "post": {
+        "/admin/suggested-goals": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Generate excuse candidates using AI. Requires premium plan.",
+                "description": "List every suggestion including inactive ones, with both titles. Requires the admin:catalog permission.",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,62 +30,40 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ai"
-                ],
-                "summary": "Generate AI excuses",
-                "parameters": [
-                    {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.CreateAiExcuseRequest"
-                        }
-                    }
+                    "admin"
                 ],
+                "summary": "List suggested goals (admin)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateAiExcuseResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.AiUnauthorizedResponse"
+                            "$ref": "#/definitions/handlers.GetAdminSuggestedGoalsResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden if not premium",
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PremiumRequiredResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.InternalErrorResponse"
+                            "$ref": "#/definitions/handlers.SuggestedGoalFetchErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/excuse-templates": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get excuse templates. Can filter by pack_id. Premium users can access all. Free users restricted from premium packs.",
+                "description": "Add an entry to the catalogue. Requires the admin:catalog permission.",
                 "consumes": [
                     "application/json"
                 ],
@@ -93,96 +71,79 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "excuse-templates"
+                    "admin"
                 ],
-                "summary": "List excuse templates",
+                "summary": "Create suggested goal (admin)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Pack ID to filter",
-                        "name": "pack_id",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.GetExcuseTemplatesResponse"
+                            "$ref": "#/definitions/handlers.CreateSuggestedGoalRequest"
                         }
                     }
-                }
-            }
-        },
-        "/excuse-templates/{id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "excuse-templates"
-                ],
-                "summary": "Get template details",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Template ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseTemplateResponse"
+                            "$ref": "#/definitions/handlers.AdminSuggestedGoalResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.TemplateUnauthorizedResponse"
+                            "$ref": "#/definitions/handlers.SuggestedGoalValidationErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/handlers.TemplateNotFoundResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuggestedGoalConflictResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.TemplateInternalErrorResponse"
+                            "$ref": "#/definitions/handlers.SuggestedGoalSaveErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/excuses/{id}": {
+        "/admin/suggested-goals/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Remove an entry from the catalogue. Goals already created from it are not affected. Requires the admin:catalog permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "excuses"
+                    "admin"
                 ],
-                "summary": "Delete an excuse",
+                "summary": "Delete suggested goal (admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Excuse ID",
+                        "description": "Suggested goal ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -192,28 +153,25 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
-                    "400": {
-                        "description": "Invalid Excuse ID",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseNotFoundResponse"
+                            "$ref": "#/definitions/handlers.SuggestedGoalNotFoundResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseDeleteErrorResponse"
+                            "$ref": "#/definitions/handlers.SuggestedGoalSaveErrorResponse"
                         }
                     }
                 }
@@ -224,6 +182,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
+                "description": "Update an entry of the catalogue. Set isActive to false to hide it without deleting it. Requires the admin:catalog permission.",
                 "consumes": [
                     "application/json"
                 ],
@@ -231,24 +190,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "excuses"
+                    "admin"
                 ],
-                "summary": "Update an excuse",
+                "summary": "Update suggested goal (admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Excuse ID",
+                        "description": "Suggested goal ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update Data",
+                        "description": "Request body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateExcuseRequest"
+                            "$ref": "#/definitions/handlers.UpdateSuggestedGoalRequest"
                         }
                     }
                 ],
@@ -256,50 +215,47 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseResponse"
+                            "$ref": "#/definitions/handlers.AdminSuggestedGoalResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                            "$ref": "#/definitions/handlers.SuggestedGoalValidationErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseForbiddenResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseNotFoundResponse"
+                            "$ref": "#/definitions/handlers.SuggestedGoalNotFoundResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseUpdateErrorResponse"
+                            "$ref": "#/definitions/handlers.SuggestedGoalSaveErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/goals": {
-            "get": {
+        "/ai-excuse": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all goals for the current user",
+                "description": "Generate excuse candidates using AI. Requires premium plan. The date follows the same rules as POST /goals/{goal_id}/excuses. Candidates failing moderation are dropped. Each generation is saved to the history.",
                 "consumes": [
                     "application/json"
                 ],
@@ -307,37 +263,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goals"
+                    "ai"
+                ],
+                "summary": "Generate AI excuses",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateAiExcuseRequest"
+                        }
+                    }
                 ],
-                "summary": "List goals",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GetGoalsResponse"
+                            "$ref": "#/definitions/handlers.CreateAiExcuseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                            "$ref": "#/definitions/handlers.AiUnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden if not premium or the tone is premium only",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ToneRequiresPremiumResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Goal not found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AiNotFoundResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseGoalArchivedResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalFetchErrorResponse"
+                            "$ref": "#/definitions/handlers.InternalErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/ai-generations": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new goal. Checks for plan limits.",
+                "description": "List past AI generations of the current user, newest first. Can filter by goal_id.",
                 "consumes": [
                     "application/json"
                 ],
@@ -345,62 +338,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "goals"
+                    "ai"
                 ],
-                "summary": "Create goal",
+                "summary": "List AI generation history",
                 "parameters": [
                     {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.CreateGoalRequest"
-                        }
+                        "type": "string",
+                        "description": "Goal ID to filter",
+                        "name": "goal_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateGoalResponse"
+                            "$ref": "#/definitions/handlers.GetAiGenerationsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalValidationErrorResponse"
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden if max goals reached",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.GoalLimitReachedResponse"
+                            "$ref": "#/definitions/handlers.AiUnauthorizedResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalCreateErrorResponse"
+                            "$ref": "#/definitions/handlers.AiHistoryFetchErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/goals/{goal_id}/excuses": {
+        "/ai-generations/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "List excuses, filters by retention days if strictly limited by entitlement.",
                 "consumes": [
                     "application/json"
                 ],
@@ -408,64 +391,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "excuses"
+                    "ai"
                 ],
-                "summary": "List excuses for a goal",
+                "summary": "Get an AI generation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Goal ID",
-                        "name": "goal_id",
+                        "description": "Generation ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "From Date (YYYY-MM-DD)",
-                        "name": "from",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "To Date (YYYY-MM-DD)",
-                        "name": "to",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GetExcusesResponse"
+                            "$ref": "#/definitions/handlers.AiGenerationResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid Goal ID",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                            "$ref": "#/definitions/handlers.AiUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AiNotFoundResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseFetchErrorResponse"
+                            "$ref": "#/definitions/handlers.AiHistoryFetchErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/ai-generations/{id}/save": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upsert excuse for a date. Checks entitlement if using premium template.",
+                "description": "Upsert the chosen candidate as the excuse for the goal and date of the original request. The same date rules as POST /goals/{goal_id}/excuses apply at the time of saving.",
                 "consumes": [
                     "application/json"
                 ],
@@ -473,28 +452,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "excuses"
+                    "ai"
                 ],
-                "summary": "Create or update an excuse",
+                "summary": "Save an AI candidate as excuse",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Goal ID",
-                        "name": "goal_id",
+                        "description": "Generation ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Excuse Data",
+                        "description": "Request body",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateExcuseRequest"
+                            "$ref": "#/definitions/handlers.SaveAiCandidateRequest"
                         }
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "Existing excuse overwritten",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseResponse"
+                        }
+                    },
                     "201": {
                         "description": "Created",
                         "schema": {
@@ -504,13 +489,13 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                            "$ref": "#/definitions/handlers.ValidationErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                            "$ref": "#/definitions/handlers.AiUnauthorizedResponse"
                         }
                     },
                     "403": {
@@ -519,6 +504,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handlers.ExcuseForbiddenResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AiNotFoundResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseGoalArchivedResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -528,13 +525,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/goals/{goal_id}/excuses/today": {
+        "/ai-tones": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "List the tones usable for AI excuses and template filtering. Labels follow Accept-Language, or the user's locale when it is not sent.",
                 "consumes": [
                     "application/json"
                 ],
@@ -542,74 +540,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "excuses"
+                    "ai"
                 ],
-                "summary": "Get today's excuse for a goal",
+                "summary": "List tones",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Goal ID",
-                        "name": "goal_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Language of labels (ja, en)",
+                        "name": "Accept-Language",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid Goal ID",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseNotFoundResponse"
+                            "$ref": "#/definitions/handlers.GetTonesResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ExcuseFetchErrorResponse"
+                            "$ref": "#/definitions/handlers.ToneFetchErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/goals/{id}": {
+        "/attachments/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a single goal by ID",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
-                    "application/json"
+                    "image/jpeg",
+                    "image/png"
                 ],
                 "tags": [
-                    "goals"
+                    "attachments"
                 ],
-                "summary": "Get goal details",
+                "summary": "Download photo",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Goal ID",
+                        "description": "Attachment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -617,27 +593,33 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Image",
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateGoalResponse"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                            "$ref": "#/definitions/handlers.AttachmentUnauthorizedResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalNotFoundErrorResponse"
+                            "$ref": "#/definitions/handlers.AttachmentNotFoundResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalFetchErrorResponse"
+                            "$ref": "#/definitions/handlers.AttachmentFetchErrorResponse"
                         }
                     }
                 }
@@ -648,20 +630,15 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Delete the photo and its thumbnail right away. Photos of deleted excuses are removed when the excuse is purged from the trash.",
                 "tags": [
-                    "goals"
+                    "attachments"
                 ],
-                "summary": "Delete goal",
+                "summary": "Delete photo",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Goal ID",
+                        "description": "Attachment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -671,102 +648,99 @@ const docTemplate = `{
                     "204": {
                         "description": "No Content"
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                            "$ref": "#/definitions/handlers.AttachmentUnauthorizedResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalNotFoundErrorResponse"
+                            "$ref": "#/definitions/handlers.AttachmentNotFoundResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalDeleteErrorResponse"
+                            "$ref": "#/definitions/handlers.AttachmentSaveErrorResponse"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/attachments/{id}/thumbnail": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "JPEG whose longer side is at most 320px.",
                 "produces": [
-                    "application/json"
+                    "image/jpeg"
                 ],
                 "tags": [
-                    "goals"
+                    "attachments"
                 ],
-                "summary": "Update goal",
+                "summary": "Download photo thumbnail",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Goal ID",
+                        "description": "Attachment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateGoalRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Image",
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateGoalResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalValidationErrorResponse"
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                            "$ref": "#/definitions/handlers.AttachmentUnauthorizedResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalNotFoundErrorResponse"
+                            "$ref": "#/definitions/handlers.AttachmentNotFoundResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GoalUpdateErrorResponse"
+                            "$ref": "#/definitions/handlers.AttachmentFetchErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/me/plan": {
+        "/excuse-templates": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the user's current subscription plan and their active entitlements.",
+                "description": "Get excuse templates. Can filter by pack_id and tone. Premium users can access all. Free users restricted from premium packs.",
                 "consumes": [
                     "application/json"
                 ],
@@ -774,431 +748,5129 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "plan"
+                    "excuse-templates"
+                ],
+                "summary": "List excuse templates",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pack ID to filter",
+                        "name": "pack_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tone ID to filter",
+                        "name": "tone",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get current user plan and entitlements",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.GetMePlanResponse"
+                            "$ref": "#/definitions/handlers.GetExcuseTemplatesResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/excuse-templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuse-templates"
+                ],
+                "summary": "Get template details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseTemplateResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PlanUnauthorizedResponse"
+                            "$ref": "#/definitions/handlers.TemplateUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TemplateNotFoundResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PlanFetchErrorResponse"
+                            "$ref": "#/definitions/handlers.TemplateInternalErrorResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/excuses/search": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the user's subscription plan (e.g., from 'free' to 'premium').",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Find the caller's excuses across all goals whose text contains every space-separated term of q, ignoring case. Works for Japanese without word breaks. Newest first, with the matched ranges of each text for highlighting, in UTF-16 code units. Free plans only search within logRetentionDays.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "plan"
+                    "excuses"
                 ],
-                "summary": "Update user plan",
+                "summary": "Search excuses",
                 "parameters": [
                     {
-                        "description": "Request body",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.PostMePlanRequest"
-                        }
+                        "type": "string",
+                        "description": "Search terms, separated by spaces (max 100 characters)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "goalId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From Date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To Date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max results (1-100, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Results to skip (default 0)",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PostMePlanResponse"
+                            "$ref": "#/definitions/handlers.SearchExcusesResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PlanValidationErrorResponse"
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PlanUnauthorizedResponse"
+                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handlers.PlanUpdateErrorResponse"
+                            "$ref": "#/definitions/handlers.ExcuseFetchErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.AiUnauthorizedResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "認証されていません"
-                }
-            }
         },
-        "handlers.CreateAiExcuseRequest": {
-            "type": "object",
-            "required": [
-                "date",
-                "goalId"
-            ],
-            "properties": {
-                "context": {
-                    "type": "string",
-                    "example": "会議が多すぎました。"
-                },
-                "date": {
+        "/excuses/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move the excuse to the trash. It can be restored until it is purged.",
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Delete an excuse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Excuse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Invalid Excuse ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseDeleteErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Update an excuse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Excuse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateExcuseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseUpdateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/excuses/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "List excuse photos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Excuse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetAttachmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AttachmentUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AttachmentFetchErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload a JPEG or PNG photo (max 10MB, 4 per excuse). A thumbnail is generated. The photos of all the user's excuses, trashed ones included, count toward the plan's maxStorageBytes (403 when exceeded).",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Attach photo to excuse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Excuse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "JPEG or PNG image",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AttachmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AttachmentValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AttachmentUnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AttachmentQuotaExceededResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AttachmentSaveErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/excuses/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take the excuse out of the trash. Fails with 409 when its goal is in the trash (restore the goal instead) or the goal's daily cap has since been lowered below it. Saving a new excuse for the same day permanently replaces the one in the trash.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore an excuse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Excuse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TrashValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TrashUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TrashNotFoundResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TrashConflictResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RestoreErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/excuses/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Newest first. Free plans only keep the revisions replaced within logRetentionDays.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "List earlier wordings of an excuse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Excuse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetExcuseRevisionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseRevisionFetchErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/excuses/{id}/revisions/{revisionId}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Put the text and template of the revision back. The current wording is kept as a new revision, so a restore can be undone.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Restore an earlier wording of an excuse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Excuse ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Revision ID",
+                        "name": "revisionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseRevisionNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseUpdateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goal-colors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the palette keys usable as a goal's color. Colors of premium palettes can be set only with the premium plan.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "List goal colors",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetGoalColorsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all goals for the current user. Archived goals are left out unless includeArchived is true.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "List goals",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include archived goals",
+                        "name": "includeArchived",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetGoalsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalFetchErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new goal. Checks for plan limits; archived goals do not count.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Create goal",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateGoalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateGoalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden if max goals reached or the color is premium only",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalLimitReachedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalCreateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the order of the goals shown by GET /goals. goalIds must list every one of them exactly once, first goal first; archived goals keep their order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Reorder goals",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReorderGoalsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetGoalsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalOrderIncompleteResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUpdateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{goal_id}/excuses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List excuses, filters by retention days if strictly limited by entitlement.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "List excuses for a goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "goal_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From Date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To Date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only excuses with this mood (1-5)",
+                        "name": "mood",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only excuses with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetExcusesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Goal ID or date",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseFetchErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upsert excuse for a date. When the day already has an excuse, its mood, tags and note are kept unless sent. For a goal whose maxExcusesPerDay is more than 1, a new excuse is added instead, up to that many a day (409 beyond). The date must be between the goal's creation day and the user's today, and within the plan's retention window (403 otherwise). Checks entitlement if using premium template. The text is moderated (rejected, masked or flagged for review).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Create or update an excuse",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "goal_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Excuse Data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateExcuseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseForbiddenResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalNotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseGoalArchivedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseCreateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{goal_id}/excuses/today": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "\"Today\" is in the user's time zone. For a goal taking several excuses a day, the latest one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Get today's excuse for a goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "goal_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Goal ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseFetchErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{goal_id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aggregate the goal's excuses: how many there are, the moods and the tags, most common first, to surface the most common reason for missing the goal. The same date filters and retention window as GET /goals/{goal_id}/excuses apply.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "excuses"
+                ],
+                "summary": "Get excuse stats for a goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "goal_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From Date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To Date (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Goal ID or date",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExcuseFetchErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single goal by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Get goal details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateGoalResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalNotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalFetchErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move the goal and its excuses to the trash. They can be restored until they are purged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Delete goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalNotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalDeleteErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Update goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateGoalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateGoalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalPremiumColorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalNotFoundErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "maxExcusesPerDay is lower than the excuses of a day",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalDailyExcuseCapResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUpdateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hide the goal from GET /goals and stop its reminders. Its excuses can still be viewed, but no new ones can be saved. Archived goals do not count toward the plan's goal limit.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Archive goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateGoalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalNotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUpdateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{id}/pauses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the pause periods of the goal, earliest first. Vacations are listed by GET /me/vacations.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "List goal pauses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetPausePeriodsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalNotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseFetchErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Freeze the goal from startDate to endDate (both included): no reminders, no excuses, and the days do not count in the goal's summary. startDate must not be before the user's today.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "Pause goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreatePausePeriodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PausePeriodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalNotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseSaveErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{id}/pauses/{pauseId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a pause that has not started yet. A pause that is running ends yesterday instead, so the days already paused stay paused. A pause that is over cannot be cancelled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "Cancel goal pause",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pause ID",
+                        "name": "pauseId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseNotFoundResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseEndedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseSaveErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take the goal out of the trash, with the excuses deleted together with it. Restored goals count toward the plan's goal limit.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore a goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TrashValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TrashUnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalLimitReachedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TrashNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RestoreErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{id}/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the result of a goal with an end date: days in the period, days with an excuse and the success rate. Paused days are counted apart and left out of the rate. It is written shortly after the end date passes in the user's time zone, when the goal is archived.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Get goal summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "The goal does not exist or its period has not ended yet",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalSummaryNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalFetchErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/goals/{id}/unarchive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bring an archived goal back. It counts toward the plan's goal limit again (403 when the limit is reached).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Unarchive goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateGoalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalLimitReachedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalNotFoundErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUpdateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the user's profile and preferences. The user is created on the first authenticated request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserFetchErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Schedule the account and all of its data (goals, excuses, plan, AI history, devices...) for deletion. The deletion can be cancelled until deletionScheduledAt. When no grace period is configured the account is deleted immediately (204).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Delete account",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AccountDeletionResponse"
+                        }
+                    },
+                    "204": {
+                        "description": "Deleted immediately"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AccountDeletionErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the given profile and preference fields. Omitted fields are left as they are.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update profile",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserUpdateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/cancel-deletion": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Undo DELETE /me while the grace period is running.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Cancel account deletion",
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AccountDeletionNotScheduledResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AccountDeletionErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/devices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the devices registered for push notifications by the current user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "List devices",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetDevicesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceFetchErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a push token for the current user. Registering a known token refreshes it (and moves it from another account). When more than 10 devices are registered, the least recently seen ones are removed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Register device",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RegisterDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Already registered token refreshed",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceRegisterErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/devices/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "devices"
+                ],
+                "summary": "Unregister device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeviceDeleteErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/exports": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start building a ZIP of the user's profile, plan, goals, excuses (JSON and CSV) and AI history. The archive is built in the background; poll GET /me/exports/{id} until it is completed. An export already in progress is returned instead of starting another one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Request data export",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportCreateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/exports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Get data export status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportFetchErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/exports/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "exports"
+                ],
+                "summary": "Download data export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Export ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ZIP archive",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportNotFoundResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportNotReadyResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ExportFetchErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/imports": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Import excuses from a CSV file (columns date, goal, excuse; the CSV of the data export works as is) or the Checkmarks.csv of a Loop Habit Tracker export (missed days become excuses). Goals are matched by title and created when missing, within the plan's goal limit. Rows follow the rules of POST /goals/{goal_id}/excuses and overwrite the excuse of the same day. Rows that fail are reported and skipped. With dryRun=true nothing is saved.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Import excuse history",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file (max 5MB, 5000 rows)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv (default) or loop",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Preview without saving",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/plan": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the user's current subscription plan and their active entitlements.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plan"
+                ],
+                "summary": "Get current user plan and entitlements",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetMePlanResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PlanUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PlanFetchErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the user's subscription plan (e.g., from 'free' to 'premium').",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "plan"
+                ],
+                "summary": "Update user plan",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PostMePlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PostMePlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PlanValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PlanUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PlanUpdateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/timezone": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the user's time zone, used for \"today\", log retention, date validation and notification times. Defaults to the X-Time-Zone header of the first request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get time zone",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TimeZoneResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserFetchErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update time zone",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateTimeZoneRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TimeZoneResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Unknown time zone",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UserUpdateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/vacations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the vacations of the current user, earliest first. A vacation pauses every goal.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "List vacations",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetPausePeriodsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseFetchErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pause every goal of the current user from startDate to endDate (both included), including goals created during the vacation. startDate must not be before the user's today.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "Start vacation",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreatePausePeriodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PausePeriodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseSaveErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/vacations/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a vacation that has not started yet. A vacation that is running ends yesterday instead. A vacation that is over cannot be cancelled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pauses"
+                ],
+                "summary": "Cancel vacation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Vacation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseUnauthorizedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseNotFoundResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseEndedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PauseSaveErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggested-goals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the curated goal suggestions shown on the goal creation screen. Titles follow Accept-Language, or the user's locale when it is not sent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "List suggested goals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Language of titles (ja, en)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "health",
+                            "study",
+                            "lifestyle",
+                            "mind",
+                            "work"
+                        ],
+                        "type": "string",
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GetSuggestedGoalsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuggestedGoalFetchErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/suggested-goals/{id}/goals": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a goal prefilled from a suggestion: the localized title, its icon and, when set, its default notification time. Goes through the same plan limit as POST /goals.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "goals"
+                ],
+                "summary": "Create goal from suggestion",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Language of the goal title (ja, en)",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Suggested goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateGoalResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalUnauthorizedResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalLimitReachedResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SuggestedGoalNotFoundResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GoalCreateErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return the goals and excuses changed, and the ones deleted, since the cursor of the previous pull. Omit the cursor for the first sync. Excuses outside the plan's retention window are not returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Pull changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous pull",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SyncPullResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SyncValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SyncUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SyncErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply a batch of changes made offline, in order. IDs of new goals and excuses are generated by the client. With baseVersion the change is applied only if the row is still at that version (conflict otherwise, with the current row); without it the last write wins. Changes follow the same rules as the REST endpoints and are rejected with a message otherwise.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Push changes",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SyncPushRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SyncPushResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SyncValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SyncUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SyncErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return the deleted goals and excuses that can still be restored, most recently deleted first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "List the trash",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TrashResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TrashUnauthorizedResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TrashErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.AccountDeletionErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "アカウントの削除に失敗しました"
+                }
+            }
+        },
+        "handlers.AccountDeletionNotScheduledResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "削除予定はありません"
+                }
+            }
+        },
+        "handlers.AccountDeletionResponse": {
+            "type": "object",
+            "properties": {
+                "deletionScheduledAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AdminSuggestedGoalResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "study"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "defaultNotificationTime": {
+                    "type": "string",
+                    "example": "21:00"
+                },
+                "icon": {
+                    "type": "string",
+                    "example": "📚"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "read-10-pages"
+                },
+                "isActive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recommendedSchedule": {
+                    "type": "string",
+                    "example": "daily"
+                },
+                "titleEn": {
+                    "type": "string",
+                    "example": "Read 10 pages"
+                },
+                "titleJa": {
+                    "type": "string",
+                    "example": "本を10ページ読む"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AiGenerationResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "急な緊急対応が入りました。",
+                        "体調が優れませんでした。"
+                    ]
+                },
+                "context": {
+                    "type": "string",
+                    "example": "会議が多すぎました。"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2023-10-27"
+                },
+                "goalId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "model": {
+                    "type": "string",
+                    "example": "mock"
+                },
+                "tone": {
+                    "type": "string",
+                    "example": "serious"
+                }
+            }
+        },
+        "handlers.AiHistoryFetchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "AI言い訳履歴の取得に失敗しました"
+                }
+            }
+        },
+        "handlers.AiNotFoundResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "AI言い訳履歴が見つかりません"
+                }
+            }
+        },
+        "handlers.AiUnauthorizedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "認証されていません"
+                }
+            }
+        },
+        "handlers.AttachmentFetchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "写真の取得に失敗しました"
+                }
+            }
+        },
+        "handlers.AttachmentNotFoundResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "写真が見つかりません"
+                }
+            }
+        },
+        "handlers.AttachmentQuotaExceededResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "プランの保存容量を超えています"
+                }
+            }
+        },
+        "handlers.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string",
+                    "example": "image/jpeg"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "excuseId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "height": {
+                    "type": "integer",
+                    "example": 1440
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 482133
+                },
+                "thumbnailUrl": {
+                    "type": "string",
+                    "example": "/api/v1/attachments/550e8400-e29b-41d4-a716-446655440003/thumbnail"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "/api/v1/attachments/550e8400-e29b-41d4-a716-446655440003"
+                },
+                "width": {
+                    "type": "integer",
+                    "example": 1920
+                }
+            }
+        },
+        "handlers.AttachmentSaveErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "写真の保存に失敗しました"
+                }
+            }
+        },
+        "handlers.AttachmentUnauthorizedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "認証されていません"
+                }
+            }
+        },
+        "handlers.AttachmentValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "JPEGかPNGの画像を選んでください"
+                }
+            }
+        },
+        "handlers.CreateAiExcuseRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "goalId"
+            ],
+            "properties": {
+                "context": {
+                    "type": "string",
+                    "example": "会議が多すぎました。"
+                },
+                "date": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-27"
+                },
+                "goalId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "tone": {
+                    "description": "Tone ID from GET /ai-tones",
+                    "type": "string",
+                    "example": "serious"
+                }
+            }
+        },
+        "handlers.CreateAiExcuseResponse": {
+            "type": "object",
+            "properties": {
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "急な緊急対応が入りました。",
+                        "体調が優れませんでした。"
+                    ]
+                },
+                "generationId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                }
+            }
+        },
+        "handlers.CreateExcuseRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "excuseText"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-27"
+                },
+                "excuseText": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "寝坊しました。"
+                },
+                "mood": {
+                    "description": "1 (fine) to 5 (terrible)",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 3
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "次は前日に準備する"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "仕事",
+                        "体調"
+                    ]
+                },
+                "templateId": {
+                    "type": "string",
+                    "example": "template_123"
+                }
+            }
+        },
+        "handlers.CreateGoalRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "color": {
+                    "description": "Palette key (GET /goal-colors) or \"#RRGGBB\", which needs premium unless it is a basic palette color",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "blue"
+                },
+                "coverImageUrl": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/covers/books.jpg"
+                },
+                "endDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-30"
+                },
+                "icon": {
+                    "type": "string",
+                    "maxLength": 16,
+                    "example": "📚"
+                },
+                "maxExcusesPerDay": {
+                    "description": "Default 1",
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 3
+                },
+                "notificationEnabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "notificationTime": {
+                    "type": "string",
+                    "example": "20:00"
+                },
+                "startDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-01"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "本を10ページ読む"
+                }
+            }
+        },
+        "handlers.CreateGoalResponse": {
+            "type": "object",
+            "properties": {
+                "goal": {
+                    "$ref": "#/definitions/handlers.GoalResponse"
+                }
+            }
+        },
+        "handlers.CreatePausePeriodRequest": {
+            "type": "object",
+            "required": [
+                "endDate",
+                "startDate"
+            ],
+            "properties": {
+                "endDate": {
+                    "description": "Included",
+                    "type": "string",
+                    "format": "date",
+                    "example": "2024-01-03"
+                },
+                "startDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-12-28"
+                }
+            }
+        },
+        "handlers.CreateSuggestedGoalRequest": {
+            "type": "object",
+            "required": [
+                "category",
+                "id",
+                "recommendedSchedule",
+                "titleEn",
+                "titleJa"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "health",
+                        "study",
+                        "lifestyle",
+                        "mind",
+                        "work"
+                    ],
+                    "example": "study"
+                },
+                "defaultNotificationTime": {
+                    "type": "string",
+                    "example": "21:00"
+                },
+                "icon": {
+                    "type": "string",
+                    "maxLength": 16,
+                    "example": "📚"
+                },
+                "id": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "read-10-pages"
+                },
+                "isActive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "recommendedSchedule": {
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekdays",
+                        "weekends"
+                    ],
+                    "example": "daily"
+                },
+                "titleEn": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Read 10 pages"
+                },
+                "titleJa": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "本を10ページ読む"
+                }
+            }
+        },
+        "handlers.DeviceDeleteErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "デバイスの削除に失敗しました"
+                }
+            }
+        },
+        "handlers.DeviceFetchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "デバイスの取得に失敗しました"
+                }
+            }
+        },
+        "handlers.DeviceNotFoundResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "デバイスが見つかりません"
+                }
+            }
+        },
+        "handlers.DeviceRegisterErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "デバイスの登録に失敗しました"
+                }
+            }
+        },
+        "handlers.DeviceResponse": {
+            "type": "object",
+            "properties": {
+                "appVersion": {
+                    "type": "string",
+                    "example": "1.2.0"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "lastSeenAt": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "ja-JP"
+                },
+                "platform": {
+                    "type": "string",
+                    "example": "ios"
+                },
+                "pushToken": {
+                    "type": "string",
+                    "example": "740f4707bebcf74f9b7c25d48e3358945f6aa01da5ddb387462c7eaf61bb78ad"
+                }
+            }
+        },
+        "handlers.DeviceUnauthorizedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "認証されていません"
+                }
+            }
+        },
+        "handlers.DeviceValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "入力内容が正しくありません"
+                }
+            }
+        },
+        "handlers.ExcuseCreateErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "言い訳の作成に失敗しました"
+                }
+            }
+        },
+        "handlers.ExcuseDeleteErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "言い訳の削除に失敗しました"
+                }
+            }
+        },
+        "handlers.ExcuseFetchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "言い訳の取得に失敗しました"
+                }
+            }
+        },
+        "handlers.ExcuseForbiddenResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "プレミアムテンプレートを利用するにはプレミアムプランが必要です"
+                }
+            }
+        },
+        "handlers.ExcuseGoalArchivedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "アーカイブした目標には言い訳を保存できません"
+                }
+            }
+        },
+        "handlers.ExcuseNotFoundResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "言い訳が見つかりません"
+                }
+            }
+        },
+        "handlers.ExcuseResponse": {
+            "type": "object",
+            "properties": {
+                "aiGenerationId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2023-10-27"
+                },
+                "excuseText": {
+                    "type": "string",
+                    "example": "寝坊しました。"
+                },
+                "goalId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "mood": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "note": {
+                    "type": "string",
+                    "example": "次は前日に準備する"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "仕事",
+                        "体調"
+                    ]
+                },
+                "templateId": {
+                    "type": "string",
+                    "example": "template_123"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.ExcuseRevisionFetchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "履歴の取得に失敗しました"
+                }
+            }
+        },
+        "handlers.ExcuseRevisionNotFoundResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "履歴が見つかりません"
+                }
+            }
+        },
+        "handlers.ExcuseRevisionResponse": {
+            "type": "object",
+            "properties": {
+                "aiGenerationId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "excuseId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "excuseText": {
+                    "type": "string",
+                    "example": "月が綺麗だったので走れませんでした。"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440004"
+                },
+                "replacedAt": {
+                    "description": "When it was overwritten",
+                    "type": "string"
+                },
+                "templateId": {
+                    "type": "string",
+                    "example": "template_123"
+                },
+                "writtenAt": {
+                    "description": "When this wording was saved",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ExcuseTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "excuseText": {
+                    "type": "string",
+                    "example": "宿題を犬に食べられました。"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "template_123"
+                },
+                "isPremium": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "packId": {
+                    "type": "string",
+                    "example": "pack_abc"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "面白い",
+                        "定番"
+                    ]
+                },
+                "tones": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "casual",
+                        "serious"
+                    ]
+                }
+            }
+        },
+        "handlers.ExcuseUnauthorizedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "認証されていません"
+                }
+            }
+        },
+        "handlers.ExcuseUpdateErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "言い訳の更新に失敗しました"
+                }
+            }
+        },
+        "handlers.ExcuseValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "入力内容が正しくありません"
+                }
+            }
+        },
+        "handlers.ExportCreateErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "エクスポートの作成に失敗しました"
+                }
+            }
+        },
+        "handlers.ExportFetchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "エクスポートの取得に失敗しました"
+                }
+            }
+        },
+        "handlers.ExportNotFoundResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "エクスポートが見つかりません"
+                }
+            }
+        },
+        "handlers.ExportNotReadyResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "エクスポートはまだ完了していません"
+                }
+            }
+        },
+        "handlers.ExportResponse": {
+            "type": "object",
+            "properties": {
+                "completedAt": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "downloadUrl": {
+                    "type": "string",
+                    "example": "/api/v1/me/exports/550e8400-e29b-41d4-a716-446655440003/download"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440003"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 20480
+                },
+                "status": {
+                    "description": "\"pending\", \"processing\", \"completed\", \"failed\"",
+                    "type": "string",
+                    "example": "completed"
+                }
+            }
+        },
+        "handlers.ExportUnauthorizedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "認証されていません"
+                }
+            }
+        },
+        "handlers.ExportValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "入力内容が正しくありません"
+                }
+            }
+        },
+        "handlers.GetAdminSuggestedGoalsResponse": {
+            "type": "object",
+            "properties": {
+                "suggestedGoals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.AdminSuggestedGoalResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GetAiGenerationsResponse": {
+            "type": "object",
+            "properties": {
+                "generations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.AiGenerationResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GetAttachmentsResponse": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.AttachmentResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GetDevicesResponse": {
+            "type": "object",
+            "properties": {
+                "devices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.DeviceResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GetExcuseRevisionsResponse": {
+            "type": "object",
+            "properties": {
+                "revisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ExcuseRevisionResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GetExcuseTemplatesResponse": {
+            "type": "object",
+            "properties": {
+                "templates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ExcuseTemplateResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GetExcusesResponse": {
+            "type": "object",
+            "properties": {
+                "excuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ExcuseResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GetGoalColorsResponse": {
+            "type": "object",
+            "properties": {
+                "colors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.GoalColorResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GetGoalsResponse": {
+            "type": "object",
+            "properties": {
+                "goals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.GoalResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GetMePlanResponse": {
+            "type": "object",
+            "properties": {
+                "entitlements": {
+                    "description": "Entitlements struct might need examples in its own definition if not here",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/services.Entitlements"
+                        }
+                    ]
+                },
+                "plan": {
+                    "type": "string",
+                    "example": "premium"
+                }
+            }
+        },
+        "handlers.GetPausePeriodsResponse": {
+            "type": "object",
+            "properties": {
+                "pauses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.PausePeriodResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GetSuggestedGoalsResponse": {
+            "type": "object",
+            "properties": {
+                "suggestedGoals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SuggestedGoalResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GetTonesResponse": {
+            "type": "object",
+            "properties": {
+                "tones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ToneResponse"
+                    }
+                }
+            }
+        },
+        "handlers.GoalColorResponse": {
+            "type": "object",
+            "properties": {
+                "hex": {
+                    "type": "string",
+                    "example": "#F4A7B9"
+                },
+                "isPremium": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "key": {
+                    "type": "string",
+                    "example": "sakura"
+                },
+                "palette": {
+                    "type": "string",
+                    "example": "wa"
+                }
+            }
+        },
+        "handlers.GoalCreateErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "目標の作成に失敗しました"
+                }
+            }
+        },
+        "handlers.GoalDailyExcuseCapResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "1日の上限より多く言い訳を保存した日があるため、上限を下げられません"
+                }
+            }
+        },
+        "handlers.GoalDeleteErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "目標の削除に失敗しました"
+                }
+            }
+        },
+        "handlers.GoalFetchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "目標の取得に失敗しました"
+                }
+            }
+        },
+        "handlers.GoalLimitReachedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "プランの目標作成数上限に達しました"
+                }
+            }
+        },
+        "handlers.GoalNotFoundErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "目標が見つかりません"
+                }
+            }
+        },
+        "handlers.GoalOrderIncompleteResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "すべての目標を指定してください"
+                }
+            }
+        },
+        "handlers.GoalPremiumColorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "プレミアムカラーを利用するにはプレミアムプランが必要です"
+                }
+            }
+        },
+        "handlers.GoalResponse": {
+            "type": "object",
+            "properties": {
+                "archivedAt": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string",
+                    "example": "blue"
+                },
+                "coverImageUrl": {
+                    "type": "string",
+                    "example": "https://example.com/covers/books.jpg"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-30"
+                },
+                "icon": {
+                    "type": "string",
+                    "example": "📚"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "maxExcusesPerDay": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "notificationEnabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "notificationTime": {
+                    "type": "string",
+                    "example": "20:00"
+                },
+                "order": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "startDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-01"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "本を10ページ読む"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handlers.GoalStatsResponse": {
+            "type": "object",
+            "properties": {
+                "averageMood": {
+                    "description": "Over the excuses with a mood",
+                    "type": "number",
+                    "example": 3.2
+                },
+                "excuseCount": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "moodCounts": {
+                    "description": "Moods that occur, 1 first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.MoodCount"
+                    }
+                },
+                "mostCommonTag": {
+                    "description": "The most common reason, absent when no excuse has tags",
+                    "type": "string",
+                    "example": "仕事"
+                },
+                "tagCounts": {
+                    "description": "Most frequent first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.TagCount"
+                    }
+                }
+            }
+        },
+        "handlers.GoalSummaryNotFoundResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "目標のまとめはまだありません"
+                }
+            }
+        },
+        "handlers.GoalSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "days": {
+                    "description": "Paused days excluded",
+                    "type": "integer",
+                    "example": 30
+                },
+                "endDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-30"
+                },
+                "excuseDays": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "goalId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "pausedDays": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "startDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-01"
+                },
+                "successRate": {
+                    "description": "Share of days without an excuse, 0 to 1",
+                    "type": "number",
+                    "example": 0.8
+                }
+            }
+        },
+        "handlers.GoalUnauthorizedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "認証されていません"
+                }
+            }
+        },
+        "handlers.GoalUpdateErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "目標の更新に失敗しました"
+                }
+            }
+        },
+        "handlers.GoalValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "入力内容が正しくありません"
+                }
+            }
+        },
+        "handlers.ImportErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "取り込みに失敗しました"
+                }
+            }
+        },
+        "handlers.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ImportRowError"
+                    }
+                },
+                "excusesCreated": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "excusesUpdated": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "goalsCreated": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handlers.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "未来の日付には保存できません"
+                },
+                "line": {
+                    "description": "Line in the file, the header being line 1",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.ImportUnauthorizedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "認証されていません"
+                }
+            }
+        },
+        "handlers.ImportValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "必要な列がありません"
+                }
+            }
+        },
+        "handlers.InternalErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "AI言い訳の生成に失敗しました"
+                }
+            }
+        },
+        "handlers.MoodCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "mood": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.PauseEndedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "終了した休止期間は取り消せません"
+                }
+            }
+        },
+        "handlers.PauseFetchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "休止期間の取得に失敗しました"
+                }
+            }
+        },
+        "handlers.PauseNotFoundResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "休止期間が見つかりません"
+                }
+            }
+        },
+        "handlers.PausePeriodResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2024-01-03"
+                },
+                "goalId": {
+                    "description": "Absent for a vacation",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "startDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-12-28"
+                }
+            }
+        },
+        "handlers.PauseSaveErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "休止期間の保存に失敗しました"
+                }
+            }
+        },
+        "handlers.PauseUnauthorizedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "認証されていません"
+                }
+            }
+        },
+        "handlers.PauseValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "終了日は開始日以降の日付にしてください"
+                }
+            }
+        },
+        "handlers.PlanFetchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "プランの取得に失敗しました"
+                }
+            }
+        },
+        "handlers.PlanUnauthorizedResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "認証されていません"
+                }
+            }
+        },
+        "handlers.PlanUpdateErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "プランの更新に失敗しました"
+                }
+            }
+        },
+        "handlers.PlanValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "入力内容が正しくありません"
+                }
+            }
+        },
+        "handlers.PostMePlanRequest": {
+            "type": "object",
+            "required": [
+                "plan"
+            ],
+            "properties": {
+                "plan": {
+                    "type": "string",
+                    "example": "premium"
+                }
+            }
+        },
+        "handlers.PostMePlanResponse": {
+            "type": "object",
+            "properties": {
+                "entitlements": {
+                    "$ref": "#/definitions/services.Entitlements"
+                },
+                "plan": {
+                    "type": "string",
+                    "example": "premium"
+                }
+            }
+        },
+        "handlers.RegisterDeviceRequest": {
+            "type": "object",
+            "required": [
+                "platform",
+                "pushToken"
+            ],
+            "properties": {
+                "appVersion": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "1.2.0"
+                },
+                "locale": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "ja-JP"
+                },
+                "platform": {
+                    "type": "string",
+                    "enum": [
+                        "ios",
+                        "android"
+                    ],
+                    "example": "ios"
+                },
+                "pushToken": {
+                    "type": "string",
+                    "maxLength": 512,
+                    "example": "740f4707bebcf74f9b7c25d48e3358945f6aa01da5ddb387462c7eaf61bb78ad"
+                }
+            }
+        },
+        "handlers.ReorderGoalsRequest": {
+            "type": "object",
+            "required": [
+                "goalIds"
+            ],
+            "properties": {
+                "goalIds": {
+                    "type": "array",
+                    "maxItems": 1000,
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440001",
+                        "550e8400-e29b-41d4-a716-446655440000"
+                    ]
+                }
+            }
+        },
+        "handlers.RestoreErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "復元に失敗しました"
+                }
+            }
+        },
+        "handlers.SaveAiCandidateRequest": {
+            "type": "object",
+            "required": [
+                "candidateIndex"
+            ],
+            "properties": {
+                "candidateIndex": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                }
+            }
+        },
+        "handlers.SearchExcuseResult": {
+            "type": "object",
+            "properties": {
+                "excuse": {
+                    "$ref": "#/definitions/handlers.ExcuseResponse"
+                },
+                "highlights": {
+                    "description": "Where the search terms occur in excuse.excuseText",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.TextRange"
+                    }
+                }
+            }
+        },
+        "handlers.SearchExcusesResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SearchExcuseResult"
+                    }
+                },
+                "total": {
+                    "description": "Matches before limit and offset",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "handlers.SuggestedGoalConflictResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "同じIDのおすすめ目標が既にあります"
+                }
+            }
+        },
+        "handlers.SuggestedGoalFetchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "おすすめ目標の取得に失敗しました"
+                }
+            }
+        },
+        "handlers.SuggestedGoalNotFoundResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "おすすめ目標が見つかりません"
+                }
+            }
+        },
+        "handlers.SuggestedGoalResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "study"
+                },
+                "defaultNotificationTime": {
+                    "type": "string",
+                    "example": "21:00"
+                },
+                "icon": {
+                    "type": "string",
+                    "example": "📚"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "read-10-pages"
+                },
+                "recommendedSchedule": {
+                    "description": "\"daily\", \"weekdays\", \"weekends\"",
+                    "type": "string",
+                    "example": "daily"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "本を10ページ読む"
+                }
+            }
+        },
+        "handlers.SuggestedGoalSaveErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "おすすめ目標の保存に失敗しました"
+                }
+            }
+        },
+        "handlers.SuggestedGoalValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "入力内容が正しくありません"
+                }
+            }
+        },
+        "handlers.SyncDeletion": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "type": {
+                    "description": "\"goal\", \"excuse\"",
+                    "type": "string",
+                    "example": "excuse"
+                }
+            }
+        },
+        "handlers.SyncErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "同期に失敗しました"
+                }
+            }
+        },
+        "handlers.SyncExcuseInput": {
+            "type": "object",
+            "required": [
+                "date",
+                "excuseText",
+                "goalId"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-27"
+                },
+                "excuseText": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "寝坊しました。"
+                },
+                "goalId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "mood": {
+                    "description": "Unchanged when omitted",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 1,
+                    "example": 3
+                },
+                "note": {
+                    "description": "Unchanged when omitted, \"\" clears it",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "次は前日に準備する"
+                },
+                "tags": {
+                    "description": "Unchanged when omitted, [] clears them",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "仕事",
+                        "体調"
+                    ]
+                },
+                "templateId": {
+                    "type": "string",
+                    "example": "template_123"
+                }
+            }
+        },
+        "handlers.SyncGoalInput": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "blue"
+                },
+                "coverImageUrl": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://example.com/covers/books.jpg"
+                },
+                "endDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-30"
+                },
+                "icon": {
+                    "type": "string",
+                    "maxLength": 16,
+                    "example": "📚"
+                },
+                "maxExcusesPerDay": {
+                    "description": "Unchanged when omitted, 1 for a new goal",
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "notificationEnabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "notificationTime": {
+                    "type": "string",
+                    "example": "20:00"
+                },
+                "startDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-01"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "本を10ページ読む"
+                }
+            }
+        },
+        "handlers.SyncMutation": {
+            "type": "object",
+            "required": [
+                "id",
+                "type"
+            ],
+            "properties": {
+                "baseVersion": {
+                    "description": "Version the client edited; omit for last-write-wins",
+                    "type": "integer",
+                    "example": 2
+                },
+                "clientMutationId": {
+                    "description": "Echoed back in the result",
                     "type": "string",
-                    "example": "2023-10-27"
+                    "example": "m-1"
                 },
-                "goalId": {
+                "excuse": {
+                    "description": "For upsert_excuse",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handlers.SyncExcuseInput"
+                        }
+                    ]
+                },
+                "goal": {
+                    "description": "For upsert_goal",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handlers.SyncGoalInput"
+                        }
+                    ]
+                },
+                "id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "tone": {
+                "type": {
                     "type": "string",
-                    "example": "真面目"
+                    "enum": [
+                        "upsert_goal",
+                        "delete_goal",
+                        "upsert_excuse",
+                        "delete_excuse"
+                    ],
+                    "example": "upsert_excuse"
                 }
             }
         },
-        "handlers.CreateAiExcuseResponse": {
+        "handlers.SyncMutationResult": {
             "type": "object",
             "properties": {
-                "candidates": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "急な緊急対応が入りました。",
-                        "体調が優れませんでした。"
+                "clientMutationId": {
+                    "type": "string",
+                    "example": "m-1"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "未来の日付には保存できません"
+                },
+                "excuse": {
+                    "description": "Row after the change, or the current row on conflict",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handlers.ExcuseResponse"
+                        }
+                    ]
+                },
+                "goal": {
+                    "description": "Row after the change, or the current row on conflict",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/handlers.GoalResponse"
+                        }
                     ]
+                },
+                "status": {
+                    "description": "\"applied\", \"conflict\", \"rejected\"",
+                    "type": "string",
+                    "example": "applied"
                 }
             }
         },
-        "handlers.CreateExcuseRequest": {
+        "handlers.SyncPullResponse": {
             "type": "object",
-            "required": [
-                "date",
-                "excuseText"
-            ],
             "properties": {
-                "date": {
-                    "description": "YYYY-MM-DD",
+                "cursor": {
+                    "description": "Pass to the next pull",
                     "type": "string",
-                    "example": "2023-10-27"
+                    "example": "1024"
                 },
-                "excuseText": {
-                    "type": "string",
-                    "maxLength": 500,
-                    "example": "寝坊しました。"
+                "deletions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SyncDeletion"
+                    }
                 },
-                "templateId": {
-                    "type": "string",
-                    "example": "template_123"
+                "excuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ExcuseResponse"
+                    }
+                },
+                "goals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.GoalResponse"
+                    }
                 }
             }
         },
-        "handlers.CreateGoalRequest": {
+        "handlers.SyncPushRequest": {
             "type": "object",
             "required": [
-                "title"
+                "mutations"
             ],
             "properties": {
-                "notificationEnabled": {
-                    "type": "boolean",
-                    "example": true
-                },
-                "notificationTime": {
-                    "type": "string",
-                    "example": "20:00"
-                },
-                "title": {
-                    "type": "string",
-                    "maxLength": 200,
-                    "example": "本を10ページ読む"
+                "mutations": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "items": {
+                        "$ref": "#/definitions/handlers.SyncMutation"
+                    }
                 }
             }
         },
-        "handlers.CreateGoalResponse": {
+        "handlers.SyncPushResponse": {
             "type": "object",
             "properties": {
-                "goal": {
-                    "$ref": "#/definitions/handlers.GoalResponse"
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.SyncMutationResult"
+                    }
                 }
             }
         },
-        "handlers.ExcuseCreateErrorResponse": {
+        "handlers.SyncUnauthorizedResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
-                    "example": "言い訳の作成に失敗しました"
+                    "example": "認証されていません"
                 }
             }
         },
-        "handlers.ExcuseDeleteErrorResponse": {
+        "handlers.SyncValidationErrorResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
-                    "example": "言い訳の削除に失敗しました"
+                    "example": "入力内容が正しくありません"
                 }
             }
         },
-        "handlers.ExcuseFetchErrorResponse": {
+        "handlers.TagCount": {
             "type": "object",
             "properties": {
-                "error": {
+                "count": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "tag": {
                     "type": "string",
-                    "example": "言い訳の取得に失敗しました"
+                    "example": "仕事"
                 }
             }
         },
-        "handlers.ExcuseForbiddenResponse": {
+        "handlers.TemplateInternalErrorResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
-                    "example": "プレミアムテンプレートを利用するにはプレミアムプランが必要です"
+                    "example": "テンプレートの取得に失敗しました"
                 }
             }
         },
-        "handlers.ExcuseNotFoundResponse": {
+        "handlers.TemplateNotFoundResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
-                    "example": "言い訳が見つかりません"
+                    "example": "テンプレートが見つかりません"
                 }
             }
         },
-        "handlers.ExcuseResponse": {
+        "handlers.TemplateUnauthorizedResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "date": {
-                    "type": "string",
-                    "example": "2023-10-27"
-                },
-                "excuseText": {
-                    "type": "string",
-                    "example": "寝坊しました。"
-                },
-                "goalId": {
+                "error": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                    "example": "認証されていません"
+                }
+            }
+        },
+        "handlers.TextRange": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer",
+                    "example": 4
                 },
-                "id": {
+                "start": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handlers.TimeZoneResponse": {
+            "type": "object",
+            "properties": {
+                "timeZone": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
-                },
-                "templateId": {
+                    "example": "Asia/Tokyo"
+                }
+            }
+        },
+        "handlers.ToneFetchErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string",
-                    "example": "template_123"
-                },
-                "updatedAt": {
-                    "type": "string"
+                    "example": "トーンの取得に失敗しました"
                 }
             }
         },
-        "handlers.ExcuseTemplateResponse": {
+        "handlers.ToneRequiresPremiumResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
-                    "type": "string"
-                },
-                "excuseText": {
+                "error": {
                     "type": "string",
-                    "example": "宿題を犬に食べられました。"
-                },
+                    "example": "このトーンを利用するにはプレミアムプランが必要です"
+                }
+            }
+        },
+        "handlers.ToneResponse": {
+            "type": "object",
+            "properties": {
                 "id": {
                     "type": "string",
-                    "example": "template_123"
+                    "example": "surreal"
                 },
                 "isPremium": {
                     "type": "boolean",
-                    "example": false
+                    "example": true
                 },
-                "packId": {
+                "label": {
                     "type": "string",
-                    "example": "pack_abc"
-                },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "面白い",
-                        "定番"
-                    ]
+                    "example": "シュール"
                 }
             }
         },
-        "handlers.ExcuseUnauthorizedResponse": {
+        "handlers.TrashConflictResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
-                    "example": "認証されていません"
+                    "example": "先に目標を復元してください"
                 }
             }
         },
-        "handlers.ExcuseUpdateErrorResponse": {
+        "handlers.TrashErrorResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
-                    "example": "言い訳の更新に失敗しました"
+                    "example": "ゴミ箱の取得に失敗しました"
                 }
             }
         },
-        "handlers.ExcuseValidationErrorResponse": {
+        "handlers.TrashNotFoundResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
-                    "example": "入力内容が正しくありません"
-                }
-            }
-        },
-        "handlers.GetExcuseTemplatesResponse": {
-            "type": "object",
-            "properties": {
-                "templates": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/handlers.ExcuseTemplateResponse"
-                    }
+                    "example": "ゴミ箱に見つかりません"
                 }
             }
         },
-        "handlers.GetExcusesResponse": {
+        "handlers.TrashResponse": {
             "type": "object",
             "properties": {
                 "excuses": {
+                    "description": "Excuses deleted on their own; those of a trashed goal are restored with it",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handlers.ExcuseResponse"
+                        "$ref": "#/definitions/handlers.TrashedExcuseResponse"
                     }
-                }
-            }
-        },
-        "handlers.GetGoalsResponse": {
-            "type": "object",
-            "properties": {
+                },
                 "goals": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/handlers.GoalResponse"
+                        "$ref": "#/definitions/handlers.TrashedGoalResponse"
                     }
                 }
             }
         },
-        "handlers.GetMePlanResponse": {
-            "type": "object",
-            "properties": {
-                "entitlements": {
-                    "description": "Entitlements struct might need examples in its own definition if not here",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/services.Entitlements"
-                        }
-                    ]
-                },
-                "plan": {
-                    "type": "string",
-                    "example": "premium"
-                }
-            }
-        },
-        "handlers.GoalCreateErrorResponse": {
+        "handlers.TrashUnauthorizedResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
-                    "example": "目標の作成に失敗しました"
+                    "example": "認証されていません"
                 }
             }
         },
-        "handlers.GoalDeleteErrorResponse": {
+        "handlers.TrashValidationErrorResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
-                    "example": "目標の削除に失敗しました"
+                    "example": "入力内容が正しくありません"
                 }
             }
         },
-        "handlers.GoalFetchErrorResponse": {
+        "handlers.TrashedExcuseResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "aiGenerationId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440002"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2023-10-27"
+                },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "excuseText": {
+                    "type": "string",
+                    "example": "寝坊しました。"
+                },
+                "goalId": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440001"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "mood": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "note": {
                     "type": "string",
-                    "example": "目標の取得に失敗しました"
-                }
-            }
-        },
-        "handlers.GoalLimitReachedResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+                    "example": "次は前日に準備する"
+                },
+                "purgeAt": {
+                    "description": "Permanently deleted after this time",
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "仕事",
+                        "体調"
+                    ]
+                },
+                "templateId": {
                     "type": "string",
-                    "example": "プランの目標作成数上限に達しました"
+                    "example": "template_123"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "handlers.GoalNotFoundErrorResponse": {
+        "handlers.TrashedGoalResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "archivedAt": {
+                    "type": "string"
+                },
+                "color": {
                     "type": "string",
-                    "example": "目標が見つかりません"
-                }
-            }
-        },
-        "handlers.GoalResponse": {
-            "type": "object",
-            "properties": {
+                    "example": "blue"
+                },
+                "coverImageUrl": {
+                    "type": "string",
+                    "example": "https://example.com/covers/books.jpg"
+                },
                 "createdAt": {
                     "type": "string"
                 },
+                "deletedAt": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-30"
+                },
+                "icon": {
+                    "type": "string",
+                    "example": "📚"
+                },
                 "id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
+                "maxExcusesPerDay": {
+                    "type": "integer",
+                    "example": 1
+                },
                 "notificationEnabled": {
                     "type": "boolean",
                     "example": true
@@ -1211,139 +5883,285 @@ const docTemplate = `{
                     "type": "integer",
                     "example": 1
                 },
+                "purgeAt": {
+                    "description": "Permanently deleted after this time",
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-01"
+                },
                 "title": {
                     "type": "string",
                     "example": "本を10ページ読む"
                 },
                 "updatedAt": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 1
                 }
             }
         },
-        "handlers.GoalUnauthorizedResponse": {
+        "handlers.UpdateExcuseRequest": {
             "type": "object",
             "properties": {
-                "error": {
+                "excuseText": {
                     "type": "string",
-                    "example": "認証されていません"
-                }
-            }
-        },
-        "handlers.GoalUpdateErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+                    "maxLength": 500,
+                    "example": "盛大に寝坊しました。"
+                },
+                "mood": {
+                    "description": "0 clears it",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0,
+                    "example": 4
+                },
+                "note": {
+                    "description": "\"\" clears it",
                     "type": "string",
-                    "example": "目標の更新に失敗しました"
-                }
-            }
-        },
-        "handlers.GoalValidationErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+                    "maxLength": 1000,
+                    "example": "雨だった"
+                },
+                "tags": {
+                    "description": "[] clears them",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "天気"
+                    ]
+                },
+                "templateId": {
                     "type": "string",
-                    "example": "入力内容が正しくありません"
+                    "example": "template_123"
                 }
             }
         },
-        "handlers.InternalErrorResponse": {
+        "handlers.UpdateGoalRequest": {
             "type": "object",
             "properties": {
-                "error": {
+                "color": {
                     "type": "string",
-                    "example": "AI言い訳の生成に失敗しました"
-                }
-            }
-        },
-        "handlers.PlanFetchErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+                    "maxLength": 32,
+                    "example": "#FF8800"
+                },
+                "coverImageUrl": {
                     "type": "string",
-                    "example": "プランの取得に失敗しました"
-                }
-            }
-        },
-        "handlers.PlanUnauthorizedResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+                    "maxLength": 2048,
+                    "example": "https://example.com/covers/run.jpg"
+                },
+                "endDate": {
                     "type": "string",
-                    "example": "認証されていません"
-                }
-            }
-        },
-        "handlers.PlanUpdateErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+                    "format": "date",
+                    "example": "2023-10-30"
+                },
+                "icon": {
+                    "description": "\"\" clears the appearance fields below",
                     "type": "string",
-                    "example": "プランの更新に失敗しました"
+                    "maxLength": 16,
+                    "example": "🏃"
+                },
+                "maxExcusesPerDay": {
+                    "description": "Lowering it keeps the excuses already saved",
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "notificationEnabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "notificationTime": {
+                    "type": "string",
+                    "example": "21:00"
+                },
+                "startDate": {
+                    "description": "\"\" clears the period; changing endDate discards the summary of the previous period",
+                    "type": "string",
+                    "format": "date",
+                    "example": "2023-10-01"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "本を20ページ読む"
                 }
             }
         },
-        "handlers.PlanValidationErrorResponse": {
+        "handlers.UpdateSuggestedGoalRequest": {
             "type": "object",
             "properties": {
-                "error": {
+                "category": {
                     "type": "string",
-                    "example": "入力内容が正しくありません"
+                    "enum": [
+                        "health",
+                        "study",
+                        "lifestyle",
+                        "mind",
+                        "work"
+                    ],
+                    "example": "study"
+                },
+                "defaultNotificationTime": {
+                    "description": "\"\" clears it",
+                    "type": "string",
+                    "example": "21:30"
+                },
+                "icon": {
+                    "description": "\"\" clears it",
+                    "type": "string",
+                    "maxLength": 16,
+                    "example": "📖"
+                },
+                "isActive": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "order": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "recommendedSchedule": {
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekdays",
+                        "weekends"
+                    ],
+                    "example": "weekdays"
+                },
+                "titleEn": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "Read 20 pages"
+                },
+                "titleJa": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "本を20ページ読む"
                 }
             }
         },
-        "handlers.PostMePlanRequest": {
+        "handlers.UpdateTimeZoneRequest": {
             "type": "object",
             "required": [
-                "plan"
+                "timeZone"
             ],
             "properties": {
-                "plan": {
+                "timeZone": {
+                    "description": "IANA name",
                     "type": "string",
-                    "example": "premium"
+                    "example": "America/Los_Angeles"
                 }
             }
         },
-        "handlers.PostMePlanResponse": {
+        "handlers.UpdateUserRequest": {
             "type": "object",
             "properties": {
-                "entitlements": {
-                    "$ref": "#/definitions/services.Entitlements"
+                "displayName": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "ねこ"
                 },
-                "plan": {
+                "locale": {
                     "type": "string",
-                    "example": "premium"
-                }
-            }
-        },
-        "handlers.PremiumRequiredResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
+                    "enum": [
+                        "ja",
+                        "en"
+                    ],
+                    "example": "en"
+                },
+                "newsNotificationsEnabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "onboardingCompleted": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "reminderNotificationsEnabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "timeZone": {
+                    "description": "IANA name",
+                    "type": "string",
+                    "example": "America/Los_Angeles"
+                },
+                "weekStartDay": {
                     "type": "string",
-                    "example": "この機能を利用するにはプレミアムプランが必要です"
+                    "enum": [
+                        "monday",
+                        "sunday"
+                    ],
+                    "example": "sunday"
                 }
             }
         },
-        "handlers.TemplateInternalErrorResponse": {
+        "handlers.UserFetchErrorResponse": {
             "type": "object",
             "properties": {
                 "error": {
                     "type": "string",
-                    "example": "テンプレートの取得に失敗しました"
+                    "example": "ユーザー情報の取得に失敗しました"
                 }
             }
         },
-        "handlers.TemplateNotFoundResponse": {
+        "handlers.UserResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deletionScheduledAt": {
+                    "description": "Set after DELETE /me until the account is deleted",
+                    "type": "string"
+                },
+                "displayName": {
                     "type": "string",
-                    "example": "テンプレートが見つかりません"
+                    "example": "ねこ"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "auth0|1234567890"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "ja"
+                },
+                "newsNotificationsEnabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "onboardingCompleted": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "reminderNotificationsEnabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "timeZone": {
+                    "type": "string",
+                    "example": "Asia/Tokyo"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "weekStartDay": {
+                    "type": "string",
+                    "example": "monday"
                 }
             }
         },
-        "handlers.TemplateUnauthorizedResponse": {
+        "handlers.UserUnauthorizedResponse": {
             "type": "object",
             "properties": {
                 "error": {
@@ -1352,35 +6170,21 @@ const docTemplate = `{
                 }
             }
         },
-        "handlers.UpdateExcuseRequest": {
+        "handlers.UserUpdateErrorResponse": {
             "type": "object",
             "properties": {
-                "excuseText": {
-                    "type": "string",
-                    "maxLength": 500,
-                    "example": "盛大に寝坊しました。"
-                },
-                "templateId": {
+                "error": {
                     "type": "string",
-                    "example": "template_123"
+                    "example": "ユーザー情報の更新に失敗しました"
                 }
             }
         },
-        "handlers.UpdateGoalRequest": {
+        "handlers.UserValidationErrorResponse": {
             "type": "object",
             "properties": {
-                "notificationEnabled": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "notificationTime": {
-                    "type": "string",
-                    "example": "21:00"
-                },
-                "title": {
+                "error": {
                     "type": "string",
-                    "maxLength": 200,
-                    "example": "本を20ページ読む"
+                    "example": "入力内容が正しくありません"
                 }
             }
         },
@@ -1399,6 +6203,9 @@ const docTemplate = `{
                 "canUseAiExcuse": {
                     "type": "boolean"
                 },
+                "canUsePremiumPalettes": {
+                    "type": "boolean"
+                },
                 "canUsePremiumTemplates": {
                     "type": "boolean"
                 },
@@ -1408,6 +6215,10 @@ const docTemplate = `{
                 },
                 "maxGoals": {
                     "type": "integer"
+                },
+                "maxStorageBytes": {
+                    "description": "Total size of the photos attached to excuses",
+                    "type": "integer"
                 }
             }
         }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -42,7 +43,8 @@ func main() {
 	dbPort := os.Getenv("POSTGRES_PORT")
 
 	// DSNを構築
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Tokyo", dbHost, dbUser, dbPassword, dbName, dbPort)
+	// 日付の判定はユーザーごとのタイムゾーンで行うため、DBセッションはUTCに固定する
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC", dbHost, dbUser, dbPassword, dbName, dbPort)
 	fmt.Println("DSN:", dsn) // Prevent unused variable error
 
 	// GORMでデータベースに接続
@@ -52,12 +54,45 @@ func main() {
 	}
 
 	// データベースにテーブルを作成
+	if err := models.CreateSyncSequence(db); err != nil {
+		log.Fatalf("Failed to create sync sequence: %v", err)
+	}
 	db.AutoMigrate(
 		&models.Goal{},
 		&models.ExcuseEntry{},
 		&models.ExcuseTemplate{},
 		&models.UserPlan{},
+		&models.PlanChange{},
+		&models.AiGeneration{},
+		&models.ModerationReview{},
+		&models.Tone{},
+		&models.NotificationLog{},
+		&models.Device{},
+		&models.User{},
+		&models.AuditLog{},
+		&models.DataExport{},
+		&models.Tombstone{},
+		&models.GoalSummary{},
+		&models.PausePeriod{},
+		&models.SuggestedGoal{},
+		&models.ExcuseAttachment{},
+		&models.ExcuseRevision{},
 	)
+	if err := models.DropSingleExcuseIndex(db); err != nil {
+		log.Fatalf("Failed to migrate excuse index: %v", err)
+	}
+	if err := models.CreateExportInProgressIndex(db); err != nil {
+		log.Fatalf("Failed to migrate export index: %v", err)
+	}
+	if err := models.CreateExcuseSearchIndex(db); err != nil {
+		// Search still works without the index, only slower
+		log.Printf("Warning: Failed to create excuse search index: %v", err)
+	}
+
+	// トーンはAI生成で参照するため本番環境でも登録する
+	if err := seed.SeedTones(db); err != nil {
+		log.Printf("Warning: Failed to seed tones: %v", err)
+	}
 
 	// 開発環境でのみ初期データをシード
 	if os.Getenv("APP_ENV") != "production" {
@@ -73,15 +108,71 @@ func main() {
 	}
 
 	// Serviceの初期化
+	moderator, err := services.NewModeratorFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize moderator: %v", err)
+	}
 	entitlementService := services.NewEntitlementService(db)
+	userService := services.NewUserService(db)
 	planHandler := handlers.NewPlanHandler(entitlementService)
-	aiService := services.NewMockAIService()
-	aiHandler := handlers.NewAIHandler(aiService)
+	userHandler := handlers.NewUserHandler(userService)
+	blobStore, err := services.NewBlobStoreFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to initialize blob store: %v", err)
+	}
+	accountDeletionService, err := services.NewAccountDeletionServiceFromEnv(db, blobStore)
+	if err != nil {
+		log.Fatalf("Failed to initialize account deletion: %v", err)
+	}
+	accountHandler := handlers.NewAccountHandler(accountDeletionService)
+	aiService := services.NewAIServiceFromEnv(db)
+	aiHandler := handlers.NewAIHandler(db, aiService, moderator)
 	goalHandler := handlers.NewGoalHandler(db)
-	excuseHandler := handlers.NewExcuseHandler(db)
+	excuseHandler := handlers.NewExcuseHandler(db, moderator)
+	excuseRevisionHandler := handlers.NewExcuseRevisionHandler(db)
 	excuseTemplateHandler := handlers.NewExcuseTemplateHandler(db)
+	toneHandler := handlers.NewToneHandler(db)
+	suggestedGoalHandler := handlers.NewSuggestedGoalHandler(db)
+	pauseHandler := handlers.NewPauseHandler(db)
+	deviceHandler := handlers.NewDeviceHandler(db)
+	exportHandler := handlers.NewExportHandler(db)
+	importHandler := handlers.NewImportHandler(db, moderator)
+	syncHandler := handlers.NewSyncHandler(db, moderator)
+	trashService, err := services.NewTrashServiceFromEnv(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize trash: %v", err)
+	}
+	trashHandler := handlers.NewTrashHandler(db, trashService.Retention())
+	attachmentHandler := handlers.NewAttachmentHandler(db, blobStore)
+
+	// 通知スケジューラーの起動
+	notifier, err := services.NewNotifierFromEnv(db)
+	if err != nil {
+		log.Fatalf("Failed to initialize notifier: %v", err)
+	}
+	notificationScheduler := services.NewNotificationScheduler(db, notifier)
+	go notificationScheduler.Run(context.Background())
+
+	// 猶予期間を過ぎたアカウントの削除
+	go accountDeletionService.Run(context.Background())
+
+	// データエクスポートの作成
+	go services.NewExportService(db).Run(context.Background())
+
+	// 保持期間を過ぎたゴミ箱の削除
+	go trashService.Run(context.Background())
+
+	// 終了日を過ぎた目標のまとめとアーカイブ
+	go services.NewChallengeService(db).Run(context.Background())
+
+	// 削除された言い訳の写真の削除
+	go services.NewAttachmentCleaner(db, blobStore).Run(context.Background())
+
+	// 保存期間を過ぎた言い訳の履歴の削除
+	go services.NewExcuseRevisionService(db).Run(context.Background())
 
 	// Middleware の初期化
+	userMiddleware := middleware.NewUserMiddleware(userService)
 	entitlementMiddleware := middleware.NewEntitlementMiddleware(entitlementService)
 
 	// Ginエンジンのインスタンスを作成
@@ -90,24 +181,76 @@ func main() {
 	docs.SwaggerInfo.BasePath = "/api/v1"
 	v1 := r.Group("/api/v1")
 	v1.Use(authMiddleware)
+	v1.Use(userMiddleware)
 	v1.Use(entitlementMiddleware)
 	{
+		v1.GET("/me", userHandler.GetMe)
+		v1.PATCH("/me", userHandler.PatchMe)
+		v1.DELETE("/me", accountHandler.DeleteMe)
+		v1.POST("/me/cancel-deletion", accountHandler.PostMeCancelDeletion)
+		v1.POST("/me/exports", exportHandler.PostMeExports)
+		v1.GET("/me/exports/:id", exportHandler.GetMeExport)
+		v1.GET("/me/exports/:id/download", exportHandler.GetMeExportDownload)
+		v1.POST("/me/imports", importHandler.PostMeImports)
+		v1.GET("/sync", syncHandler.GetSync)
+		v1.POST("/sync", syncHandler.PostSync)
 		v1.GET("/me/plan", planHandler.GetMePlan)
 		v1.POST("/me/plan", planHandler.PostMePlan)
+		v1.GET("/me/timezone", userHandler.GetMeTimeZone)
+		v1.PUT("/me/timezone", userHandler.PutMeTimeZone)
+		v1.GET("/me/devices", deviceHandler.GetMeDevices)
+		v1.POST("/me/devices", deviceHandler.PostMeDevices)
+		v1.DELETE("/me/devices/:id", deviceHandler.DeleteMeDevice)
+		v1.GET("/me/vacations", pauseHandler.GetMeVacations)
+		v1.POST("/me/vacations", pauseHandler.PostMeVacations)
+		v1.DELETE("/me/vacations/:id", pauseHandler.DeleteMeVacation)
+		v1.GET("/ai-tones", toneHandler.GetAiTones)
 		v1.POST("/ai-excuse", aiHandler.PostAiExcuse)
+		v1.GET("/ai-generations", aiHandler.GetAiGenerations)
+		v1.GET("/ai-generations/:id", aiHandler.GetAiGeneration)
+		v1.POST("/ai-generations/:id/save", aiHandler.PostAiGenerationSave)
 		v1.GET("/goals", goalHandler.GetGoals)
+		v1.GET("/goal-colors", goalHandler.GetGoalColors)
+		v1.GET("/suggested-goals", suggestedGoalHandler.GetSuggestedGoals)
+		v1.POST("/suggested-goals/:id/goals", suggestedGoalHandler.PostGoalFromSuggestion)
 		v1.POST("/goals", goalHandler.PostGoals)
+		v1.PUT("/goals/order", goalHandler.PutGoalsOrder)
 		v1.GET("/goals/:id", goalHandler.GetGoal)
 		v1.PATCH("/goals/:id", goalHandler.PatchGoal)
 		v1.DELETE("/goals/:id", goalHandler.DeleteGoal)
+		v1.POST("/goals/:id/restore", trashHandler.PostRestoreGoal)
+		v1.POST("/goals/:id/archive", goalHandler.PostArchiveGoal)
+		v1.POST("/goals/:id/unarchive", goalHandler.PostUnarchiveGoal)
+		v1.GET("/goals/:id/summary", goalHandler.GetGoalSummary)
+		v1.GET("/goals/:id/pauses", pauseHandler.GetGoalPauses)
+		v1.POST("/goals/:id/pauses", pauseHandler.PostGoalPauses)
+		v1.DELETE("/goals/:id/pauses/:pauseId", pauseHandler.DeleteGoalPause)
 		v1.GET("/excuse-templates", excuseTemplateHandler.GetExcuseTemplates)
 		v1.GET("/excuse-templates/:id", excuseTemplateHandler.GetExcuseTemplate)
 
 		v1.GET("/goals/:id/excuses", excuseHandler.GetExcuses)
 		v1.GET("/goals/:id/excuses/today", excuseHandler.GetExcuseToday)
+		v1.GET("/goals/:id/stats", excuseHandler.GetGoalStats)
 		v1.POST("/goals/:id/excuses", excuseHandler.PostExcuse)
+		v1.GET("/excuses/search", excuseHandler.SearchExcuses)
 		v1.PATCH("/excuses/:id", excuseHandler.PatchExcuse)
 		v1.DELETE("/excuses/:id", excuseHandler.DeleteExcuse)
+		v1.POST("/excuses/:id/restore", trashHandler.PostRestoreExcuse)
+		v1.GET("/excuses/:id/revisions", excuseRevisionHandler.GetExcuseRevisions)
+		v1.POST("/excuses/:id/revisions/:revisionId/restore", excuseRevisionHandler.PostRestoreExcuseRevision)
+		v1.GET("/excuses/:id/attachments", attachmentHandler.GetExcuseAttachments)
+		v1.POST("/excuses/:id/attachments", attachmentHandler.PostExcuseAttachments)
+		v1.GET("/attachments/:id", attachmentHandler.GetAttachment)
+		v1.GET("/attachments/:id/thumbnail", attachmentHandler.GetAttachmentThumbnail)
+		v1.DELETE("/attachments/:id", attachmentHandler.DeleteAttachment)
+		v1.GET("/trash", trashHandler.GetTrash)
+
+		// カタログ管理 (Auth0 RBAC の admin:catalog 権限が必要)
+		admin := v1.Group("/admin", middleware.RequirePermission("admin:catalog"))
+		admin.GET("/suggested-goals", suggestedGoalHandler.GetAdminSuggestedGoals)
+		admin.POST("/suggested-goals", suggestedGoalHandler.PostAdminSuggestedGoal)
+		admin.PATCH("/suggested-goals/:id", suggestedGoalHandler.PatchAdminSuggestedGoal)
+		admin.DELETE("/suggested-goals/:id", suggestedGoalHandler.DeleteAdminSuggestedGoal)
 	}
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 	r.Run(":8080")
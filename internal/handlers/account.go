@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AccountDeleter interface {
+	RequestDeletion(userID string) (*time.Time, error)
+	CancelDeletion(userID string) error
+}
+
+type AccountHandler struct {
+	deletionService AccountDeleter
+}
+
+func NewAccountHandler(deletionService AccountDeleter) *AccountHandler {
+	return &AccountHandler{deletionService: deletionService}
+}
+
+// DeleteMe godoc
+// @Summary Delete account
+// @Description Schedule the account and all of its data (goals, excuses, plan, AI history, devices...) for deletion. The deletion can be cancelled until deletionScheduledAt. When no grace period is configured the account is deleted immediately (204).
+// @Tags user
+// @Produce json
+// @Success 202 {object} AccountDeletionResponse
+// @Success 204 "Deleted immediately"
+// @Failure 401 {object} UserUnauthorizedResponse
+// @Failure 500 {object} AccountDeletionErrorResponse
+// @Security BearerAuth
+// @Router /me [delete]
+func (h *AccountHandler) DeleteMe(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	scheduledAt, err := h.deletionService.RequestDeletion(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "アカウントの削除に失敗しました"})
+		return
+	}
+	if scheduledAt == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, AccountDeletionResponse{DeletionScheduledAt: *scheduledAt})
+}
+
+// PostMeCancelDeletion godoc
+// @Summary Cancel account deletion
+// @Description Undo DELETE /me while the grace period is running.
+// @Tags user
+// @Produce json
+// @Success 204 "No Content"
+// @Failure 401 {object} UserUnauthorizedResponse
+// @Failure 404 {object} AccountDeletionNotScheduledResponse
+// @Failure 500 {object} AccountDeletionErrorResponse
+// @Security BearerAuth
+// @Router /me/cancel-deletion [post]
+func (h *AccountHandler) PostMeCancelDeletion(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	if err := h.deletionService.CancelDeletion(userID); err != nil {
+		if errors.Is(err, services.ErrDeletionNotScheduled) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "削除予定はありません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "アカウントの削除に失敗しました"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAccountDeleter struct {
+	mock.Mock
+}
+
+func (m *MockAccountDeleter) RequestDeletion(userID string) (*time.Time, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockAccountDeleter) CancelDeletion(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func TestDeleteMe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := "auth0|test"
+
+	t.Run("Scheduled", func(t *testing.T) {
+		mockDeleter := new(MockAccountDeleter)
+		handler := NewAccountHandler(mockDeleter)
+		scheduledAt := time.Now().Add(30 * 24 * time.Hour).UTC().Truncate(time.Second)
+		mockDeleter.On("RequestDeletion", userID).Return(&scheduledAt, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Request, _ = http.NewRequest("DELETE", "/me", nil)
+
+		handler.DeleteMe(c)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var resp AccountDeletionResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.True(t, scheduledAt.Equal(resp.DeletionScheduledAt))
+	})
+
+	t.Run("Immediate", func(t *testing.T) {
+		mockDeleter := new(MockAccountDeleter)
+		handler := NewAccountHandler(mockDeleter)
+		mockDeleter.On("RequestDeletion", userID).Return(nil, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Request, _ = http.NewRequest("DELETE", "/me", nil)
+
+		handler.DeleteMe(c)
+		// c.Status alone does not reach the recorder
+		c.Writer.WriteHeaderNow()
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
+
+func TestPostMeCancelDeletion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := "auth0|test"
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "Success", err: nil, expectedStatus: http.StatusNoContent},
+		{name: "NotScheduled", err: services.ErrDeletionNotScheduled, expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDeleter := new(MockAccountDeleter)
+			handler := NewAccountHandler(mockDeleter)
+			mockDeleter.On("CancelDeletion", userID).Return(tt.err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("userID", userID)
+			c.Request, _ = http.NewRequest("POST", "/me/cancel-deletion", nil)
+
+			handler.PostMeCancelDeletion(c)
+			// c.Status alone does not reach the recorder
+			c.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package handlers
+
+import "time"
+
+type AccountDeletionResponse struct {
+	DeletionScheduledAt time.Time `json:"deletionScheduledAt"`
+}
+
+type AccountDeletionNotScheduledResponse struct {
+	Error string `json:"error" example:"削除予定はありません"`
+}
+
+type AccountDeletionErrorResponse struct {
+	Error string `json:"error" example:"アカウントの削除に失敗しました"`
+}
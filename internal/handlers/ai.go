@@ -1,25 +1,33 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"what-went-wrong-api/internal/models"
 	"what-went-wrong-api/internal/services"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type AIHandler struct {
+	db        *gorm.DB
 	aiService services.AIService
+	moderator services.Moderator
 }
 
-func NewAIHandler(aiService services.AIService) *AIHandler {
+func NewAIHandler(db *gorm.DB, aiService services.AIService, moderator services.Moderator) *AIHandler {
 	return &AIHandler{
+		db:        db,
 		aiService: aiService,
+		moderator: moderator,
 	}
 }
 
 // PostAiExcuse godoc
 // @Summary Generate AI excuses
-// @Description Generate excuse candidates using AI. Requires premium plan.
+// @Description Generate excuse candidates using AI. Requires premium plan. The date follows the same rules as POST /goals/{goal_id}/excuses. Candidates failing moderation are dropped. Each generation is saved to the history.
 // @Tags ai
 // @Accept json
 // @Produce json
@@ -27,17 +35,19 @@ func NewAIHandler(aiService services.AIService) *AIHandler {
 // @Success 200 {object} CreateAiExcuseResponse
 // @Failure 400 {object} ValidationErrorResponse
 // @Failure 401 {object} AiUnauthorizedResponse
-// @Failure 403 {object} PremiumRequiredResponse "Forbidden if not premium"
+// @Failure 403 {object} ToneRequiresPremiumResponse "Forbidden if not premium or the tone is premium only"
+// @Failure 404 {object} AiNotFoundResponse "Goal not found"
+// @Failure 409 {object} ExcuseGoalArchivedResponse
 // @Failure 500 {object} InternalErrorResponse
 // @Security BearerAuth
 // @Router /ai-excuse [post]
 func (h *AIHandler) PostAiExcuse(c *gin.Context) {
-	// UserID extraction kept if needed for future logic (e.g. logging), otherwise remove or underscore
-	_, exists := c.Get("userID")
+	userIDStr, exists := c.Get("userID")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
 		return
 	}
+	userID := userIDStr.(string)
 
 	entitlementsInterface, exists := c.Get("entitlements")
 	if !exists {
@@ -52,16 +62,293 @@ func (h *AIHandler) PostAiExcuse(c *gin.Context) {
 	}
 
 	var req CreateAiExcuseRequest
-	if err := c.BindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": bindErrorMessage(err)})
+		return
+	}
+
+	goalID, err := uuid.Parse(req.GoalID)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
 		return
 	}
 
-	candidates, err := h.aiService.GenerateExcuse(req.Tone, req.Context)
+	var tone models.Tone
+	if req.Tone != "" {
+		if err := h.db.First(&tone, "id = ? AND is_active = ?", req.Tone, true).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "トーンの取得に失敗しました"})
+			return
+		}
+		// Premium tones follow the premium template entitlement
+		if tone.IsPremium && !entitlements.CanUsePremiumTemplates {
+			c.JSON(http.StatusForbidden, gin.H{"error": "このトーンを利用するにはプレミアムプランが必要です"})
+			return
+		}
+	}
+
+	var goal models.Goal
+	if err := h.db.First(&goal, "id = ? AND user_id = ?", goalID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "目標が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の取得に失敗しました"})
+		return
+	}
+	if ok, status, message := checkExcuseDate(c, h.db, goal, req.Date, entitlements); !ok {
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	generated, err := h.aiService.GenerateExcuse(services.ExcuseGenerationRequest{
+		GoalID:           goal.ID,
+		GoalTitle:        goal.Title,
+		Tone:             req.Tone,
+		ToneInstructions: tone.PromptInstructions,
+		Context:          req.Context,
+	})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "AI言い訳の生成に失敗しました"})
 		return
 	}
 
-	c.JSON(http.StatusOK, CreateAiExcuseResponse{Candidates: candidates})
+	candidates := make([]string, 0, len(generated.Candidates))
+	for _, candidate := range generated.Candidates {
+		moderation, err := h.moderator.Moderate(candidate)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "AI言い訳の生成に失敗しました"})
+			return
+		}
+		// Nobody wrote AI output on purpose, so flagged candidates are dropped rather than queued
+		if moderation.Rejected || moderation.Flagged {
+			continue
+		}
+		candidates = append(candidates, moderation.Text)
+	}
+	if len(candidates) == 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "AI言い訳の生成に失敗しました"})
+		return
+	}
+
+	generation := models.AiGeneration{
+		UserID:     userID,
+		GoalID:     goalID,
+		Date:       models.Date(req.Date),
+		Tone:       req.Tone,
+		Context:    req.Context,
+		Candidates: candidates,
+		Model:      generated.Model,
+	}
+	if err := h.db.Create(&generation).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "AI言い訳の保存に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, CreateAiExcuseResponse{
+		GenerationID: generation.ID,
+		Candidates:   candidates,
+	})
+}
+
+// GetAiGenerations godoc
+// @Summary List AI generation history
+// @Description List past AI generations of the current user, newest first. Can filter by goal_id.
+// @Tags ai
+// @Accept json
+// @Produce json
+// @Param goal_id query string false "Goal ID to filter"
+// @Success 200 {object} GetAiGenerationsResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} AiUnauthorizedResponse
+// @Failure 500 {object} AiHistoryFetchErrorResponse
+// @Security BearerAuth
+// @Router /ai-generations [get]
+func (h *AIHandler) GetAiGenerations(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	query := h.db.Where("user_id = ?", userID)
+	if goalIDStr := c.Query("goal_id"); goalIDStr != "" {
+		goalID, err := uuid.Parse(goalIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+			return
+		}
+		query = query.Where("goal_id = ?", goalID)
+	}
+
+	var generations []models.AiGeneration
+	if err := query.Order("created_at desc").Find(&generations).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "AI言い訳履歴の取得に失敗しました"})
+		return
+	}
+
+	res := GetAiGenerationsResponse{Generations: make([]AiGenerationResponse, len(generations))}
+	for i, g := range generations {
+		res.Generations[i] = mapToAiGenerationResponse(g)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// GetAiGeneration godoc
+// @Summary Get an AI generation
+// @Tags ai
+// @Accept json
+// @Produce json
+// @Param id path string true "Generation ID" format:uuid
+// @Success 200 {object} AiGenerationResponse
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} AiUnauthorizedResponse
+// @Failure 404 {object} AiNotFoundResponse
+// @Failure 500 {object} AiHistoryFetchErrorResponse
+// @Security BearerAuth
+// @Router /ai-generations/{id} [get]
+func (h *AIHandler) GetAiGeneration(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var generation models.AiGeneration
+	if err := h.db.First(&generation, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "AI言い訳履歴が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "AI言い訳履歴の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapToAiGenerationResponse(generation))
+}
+
+// PostAiGenerationSave godoc
+// @Summary Save an AI candidate as excuse
+// @Description Upsert the chosen candidate as the excuse for the goal and date of the original request. The same date rules as POST /goals/{goal_id}/excuses apply at the time of saving.
+// @Tags ai
+// @Accept json
+// @Produce json
+// @Param id path string true "Generation ID" format:uuid
+// @Param request body SaveAiCandidateRequest true "Request body"
+// @Success 201 {object} ExcuseResponse
+// @Success 200 {object} ExcuseResponse "Existing excuse overwritten"
+// @Failure 400 {object} ValidationErrorResponse
+// @Failure 401 {object} AiUnauthorizedResponse
+// @Failure 403 {object} ExcuseForbiddenResponse
+// @Failure 404 {object} AiNotFoundResponse
+// @Failure 409 {object} ExcuseGoalArchivedResponse
+// @Failure 500 {object} ExcuseCreateErrorResponse
+// @Security BearerAuth
+// @Router /ai-generations/{id}/save [post]
+func (h *AIHandler) PostAiGenerationSave(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	entitlementsInterface, exists := c.Get("entitlements")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "プラン情報の取得に失敗しました"})
+		return
+	}
+	entitlements := entitlementsInterface.(services.Entitlements)
+
+	var req SaveAiCandidateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+
+	var generation models.AiGeneration
+	if err := h.db.First(&generation, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "AI言い訳履歴が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "AI言い訳履歴の取得に失敗しました"})
+		return
+	}
+
+	if *req.CandidateIndex >= len(generation.Candidates) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+
+	// The goal may have been archived, deleted or paused since the generation, and the date may have left the
+	// goal's period or the plan's retention window
+	var goal models.Goal
+	if err := h.db.First(&goal, "id = ? AND user_id = ?", generation.GoalID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "目標が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の取得に失敗しました"})
+		return
+	}
+	if ok, status, message := checkExcuseDate(c, h.db, goal, Date(generation.Date), entitlements); !ok {
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
+	var excuse models.ExcuseEntry
+	var created bool
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		excuse, created, err = saveExcuseEntry(tx, goal, models.ExcuseEntry{
+			UserID:         userID,
+			GoalID:         generation.GoalID,
+			Date:           generation.Date,
+			ExcuseText:     generation.Candidates[*req.CandidateIndex],
+			AiGenerationID: &generation.ID,
+		})
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, errDailyExcuseLimitReached) {
+			c.JSON(http.StatusConflict, gin.H{"error": dailyExcuseLimitMessage(goal)})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の作成に失敗しました"})
+		return
+	}
+	if created {
+		c.JSON(http.StatusCreated, mapToResponse(excuse))
+		return
+	}
+	c.JSON(http.StatusOK, mapToResponse(excuse))
+}
+
+func mapToAiGenerationResponse(g models.AiGeneration) AiGenerationResponse {
+	return AiGenerationResponse{
+		ID:         g.ID,
+		GoalID:     g.GoalID,
+		Date:       string(g.Date),
+		Tone:       g.Tone,
+		Context:    g.Context,
+		Candidates: g.Candidates,
+		Model:      g.Model,
+		CreatedAt:  g.CreatedAt,
+	}
 }
@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
 	"what-went-wrong-api/internal/services"
 
 	"github.com/gin-gonic/gin"
@@ -19,30 +23,47 @@ type TestMockAIService struct {
 	mock.Mock
 }
 
-func (m *TestMockAIService) GenerateExcuse(tone, context string) ([]string, error) {
-	args := m.Called(tone, context)
-	return args.Get(0).([]string), args.Error(1)
+func (m *TestMockAIService) GenerateExcuse(req services.ExcuseGenerationRequest) (*services.GeneratedExcuses, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.GeneratedExcuses), args.Error(1)
 }
 
 func TestPostAiExcuse(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	t.Run("Success", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
 		mockAI := new(TestMockAIService)
-		handler := NewAIHandler(mockAI)
+		handler := NewAIHandler(db, mockAI, services.NewRuleModerator(nil))
 
 		userID := uuid.New()
-		mockAI.On("GenerateExcuse", "surreal", "context").Return([]string{"excuse 1", "excuse 2"}, nil)
+		goal := models.Goal{UserID: userID.String(), Title: "Goal"}
+		db.Create(&goal)
+		db.Create(&models.Tone{ID: "surreal", LabelJa: "シュール", LabelEn: "Surreal", PromptInstructions: "シュールに", IsPremium: true, IsActive: true})
+		mockAI.On("GenerateExcuse", services.ExcuseGenerationRequest{
+			GoalID:           goal.ID,
+			GoalTitle:        "Goal",
+			Tone:             "surreal",
+			ToneInstructions: "シュールに",
+			Context:          "context",
+		}).Return(&services.GeneratedExcuses{
+			Candidates: []string{"excuse 1", "excuse 2"},
+			Model:      "test-model",
+		}, nil)
 
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
 		c.Set("userID", userID.String())
 		// Simulate middleware setting entitlements
-		c.Set("entitlements", services.Entitlements{CanUseAiExcuse: true})
+		c.Set("entitlements", services.Entitlements{CanUseAiExcuse: true, CanUsePremiumTemplates: true})
 
 		reqBody := CreateAiExcuseRequest{
-			GoalID:  "g1",
-			Date:    "2025-01-01",
+			GoalID:  goal.ID.String(),
+			Date:    Date(testToday()),
 			Tone:    "surreal",
 			Context: "context",
 		}
@@ -56,11 +77,89 @@ func TestPostAiExcuse(t *testing.T) {
 		json.Unmarshal(w.Body.Bytes(), &resp)
 		assert.Len(t, resp.Candidates, 2)
 		assert.Equal(t, "excuse 1", resp.Candidates[0])
+
+		// Verify history is persisted
+		var generation models.AiGeneration
+		err := db.First(&generation, "id = ?", resp.GenerationID).Error
+		assert.NoError(t, err)
+		assert.Equal(t, "test-model", generation.Model)
+		assert.Equal(t, "surreal", generation.Tone)
+		assert.Equal(t, []string{"excuse 1", "excuse 2"}, []string(generation.Candidates))
+	})
+
+	t.Run("ModerationFiltersCandidates", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		mockAI := new(TestMockAIService)
+		moderator := services.NewRuleModerator([]services.ModerationRule{
+			{Pattern: regexp.MustCompile(`bad`), Action: services.ModerationActionReject},
+		})
+		handler := NewAIHandler(db, mockAI, moderator)
+
+		userID := uuid.New()
+		goal := models.Goal{UserID: userID.String(), Title: "Goal"}
+		db.Create(&goal)
+		mockAI.On("GenerateExcuse", mock.Anything).Return(&services.GeneratedExcuses{
+			Candidates: []string{"bad excuse", "good excuse"},
+			Model:      "test-model",
+		}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID.String())
+		c.Set("entitlements", services.Entitlements{CanUseAiExcuse: true})
+
+		reqBody := CreateAiExcuseRequest{GoalID: goal.ID.String(), Date: Date(testToday())}
+		jsonBytes, _ := json.Marshal(reqBody)
+		c.Request, _ = http.NewRequest("POST", "/ai-excuse", bytes.NewBuffer(jsonBytes))
+
+		handler.PostAiExcuse(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp CreateAiExcuseResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, []string{"good excuse"}, resp.Candidates)
+	})
+
+	t.Run("InvalidTone", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewAIHandler(db, new(TestMockAIService), services.NewRuleModerator(nil))
+
+		userID := uuid.New()
+		goal := models.Goal{UserID: userID.String(), Title: "Goal"}
+		db.Create(&goal)
+		db.Create(&models.Tone{ID: "surreal", LabelJa: "シュール", LabelEn: "Surreal", PromptInstructions: "シュールに", IsPremium: true, IsActive: true})
+
+		tests := []struct {
+			name           string
+			tone           string
+			expectedStatus int
+		}{
+			{name: "Unknown", tone: "真面目", expectedStatus: http.StatusBadRequest},
+			{name: "PremiumTone", tone: "surreal", expectedStatus: http.StatusForbidden},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				w := httptest.NewRecorder()
+				c, _ := gin.CreateTestContext(w)
+				c.Set("userID", userID.String())
+				c.Set("entitlements", services.Entitlements{CanUseAiExcuse: true, CanUsePremiumTemplates: false})
+
+				reqBody := CreateAiExcuseRequest{GoalID: goal.ID.String(), Date: Date(testToday()), Tone: tt.tone}
+				jsonBytes, _ := json.Marshal(reqBody)
+				c.Request, _ = http.NewRequest("POST", "/ai-excuse", bytes.NewBuffer(jsonBytes))
+
+				handler.PostAiExcuse(c)
+
+				assert.Equal(t, tt.expectedStatus, w.Code)
+			})
+		}
 	})
 
 	t.Run("Forbidden_FreePlan", func(t *testing.T) {
 		mockAI := new(TestMockAIService)
-		handler := NewAIHandler(mockAI)
+		handler := NewAIHandler(nil, mockAI, services.NewRuleModerator(nil))
 
 		userID := uuid.New()
 
@@ -82,3 +181,110 @@ func TestPostAiExcuse(t *testing.T) {
 		assert.Equal(t, http.StatusForbidden, w.Code)
 	})
 }
+
+func TestPostAiGenerationSave(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewAIHandler(db, new(TestMockAIService), services.NewRuleModerator(nil))
+
+	userID := "auth0|test"
+	today := testToday()
+	newGeneration := func(goal models.Goal) models.AiGeneration {
+		generation := models.AiGeneration{
+			UserID:     userID,
+			GoalID:     goal.ID,
+			Date:       models.Date(today),
+			Candidates: []string{"excuse 1", "excuse 2"},
+			Model:      "mock",
+		}
+		db.Create(&generation)
+		return generation
+	}
+	goal := models.Goal{UserID: userID, Title: "Goal"}
+	db.Create(&goal)
+	goalID := goal.ID
+	generation := newGeneration(goal)
+
+	saveGeneration := func(user string, generation models.AiGeneration, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", user)
+		c.Set("entitlements", services.Entitlements{})
+		c.Params = gin.Params{{Key: "id", Value: generation.ID.String()}}
+		c.Request, _ = http.NewRequest("POST", "/ai-generations/"+generation.ID.String()+"/save", strings.NewReader(body))
+		handler.PostAiGenerationSave(c)
+		return w
+	}
+	save := func(user string, body string) *httptest.ResponseRecorder {
+		return saveGeneration(user, generation, body)
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		w := save(userID, `{"candidateIndex": 1}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var resp ExcuseResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, "excuse 2", resp.ExcuseText)
+		assert.Equal(t, goalID, resp.GoalID)
+		assert.Equal(t, today, resp.Date)
+		if assert.NotNil(t, resp.AiGenerationID) {
+			assert.Equal(t, generation.ID, *resp.AiGenerationID)
+		}
+	})
+
+	t.Run("Overwrite", func(t *testing.T) {
+		w := save(userID, `{"candidateIndex": 0}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var count int64
+		db.Model(&models.ExcuseEntry{}).Where("user_id = ? AND goal_id = ?", userID, goalID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("IndexOutOfRange", func(t *testing.T) {
+		w := save(userID, `{"candidateIndex": 2}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("OtherUser", func(t *testing.T) {
+		w := save("auth0|other", `{"candidateIndex": 0}`)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("PausedSinceGeneration", func(t *testing.T) {
+		paused := models.Goal{UserID: userID, Title: "Paused"}
+		db.Create(&paused)
+		pausedGeneration := newGeneration(paused)
+		db.Create(&models.PausePeriod{UserID: userID, GoalID: &paused.ID, StartDate: models.Date(today), EndDate: models.Date(today)})
+
+		w := saveGeneration(userID, pausedGeneration, `{"candidateIndex": 0}`)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("OnStartDay", func(t *testing.T) {
+		starting := models.Goal{UserID: userID, Title: "Starting", StartDate: models.DatePtr(&today), EndDate: models.DatePtr(&today)}
+		db.Create(&starting)
+		startingGeneration := newGeneration(starting)
+
+		w := saveGeneration(userID, startingGeneration, `{"candidateIndex": 0}`)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var entry models.ExcuseEntry
+		db.First(&entry, "goal_id = ?", starting.ID)
+		assert.Equal(t, models.Date(today), entry.Date)
+	})
+
+	t.Run("AfterEndDate", func(t *testing.T) {
+		yesterday := time.Now().In(services.LoadLocation(services.DefaultTimeZone)).AddDate(0, 0, -1).Format("2006-01-02")
+		ended := models.Goal{UserID: userID, Title: "Ended"}
+		db.Create(&ended)
+		endedGeneration := newGeneration(ended)
+		db.Model(&ended).Update("end_date", yesterday)
+
+		w := saveGeneration(userID, endedGeneration, `{"candidateIndex": 0}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
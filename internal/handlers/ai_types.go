@@ -1,14 +1,40 @@
 package handlers
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type CreateAiExcuseRequest struct {
 	GoalID  string `json:"goalId" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Date    string `json:"date" binding:"required" example:"2023-10-27"`
-	Tone    string `json:"tone" example:"真面目"`
+	Date    Date   `json:"date" binding:"required" swaggertype:"string" format:"date" example:"2023-10-27"`
+	Tone    string `json:"tone" example:"serious"` // Tone ID from GET /ai-tones
 	Context string `json:"context" example:"会議が多すぎました。"`
 }
 
 type CreateAiExcuseResponse struct {
-	Candidates []string `json:"candidates" example:"急な緊急対応が入りました。,体調が優れませんでした。"`
+	GenerationID uuid.UUID `json:"generationId" example:"550e8400-e29b-41d4-a716-446655440002"`
+	Candidates   []string  `json:"candidates" example:"急な緊急対応が入りました。,体調が優れませんでした。"`
+}
+
+type AiGenerationResponse struct {
+	ID         uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	GoalID     uuid.UUID `json:"goalId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Date       string    `json:"date" example:"2023-10-27"`
+	Tone       string    `json:"tone" example:"serious"`
+	Context    string    `json:"context" example:"会議が多すぎました。"`
+	Candidates []string  `json:"candidates" example:"急な緊急対応が入りました。,体調が優れませんでした。"`
+	Model      string    `json:"model" example:"mock"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type GetAiGenerationsResponse struct {
+	Generations []AiGenerationResponse `json:"generations"`
+}
+
+type SaveAiCandidateRequest struct {
+	CandidateIndex *int `json:"candidateIndex" binding:"required,min=0" example:"0"`
 }
 
 type AiUnauthorizedResponse struct {
@@ -26,3 +52,15 @@ type PremiumRequiredResponse struct {
 type InternalErrorResponse struct {
 	Error string `json:"error" example:"AI言い訳の生成に失敗しました"`
 }
+
+type AiNotFoundResponse struct {
+	Error string `json:"error" example:"AI言い訳履歴が見つかりません"`
+}
+
+type AiHistoryFetchErrorResponse struct {
+	Error string `json:"error" example:"AI言い訳履歴の取得に失敗しました"`
+}
+
+type ToneRequiresPremiumResponse struct {
+	Error string `json:"error" example:"このトーンを利用するにはプレミアムプランが必要です"`
+}
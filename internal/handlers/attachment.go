@@ -0,0 +1,358 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxAttachmentSize      = 10 << 20
+	maxAttachmentsPerEntry = 4
+	thumbnailMaxSide       = 320
+	// Room for the multipart boundaries and part headers around an uploaded file
+	multipartOverhead = 64 << 10
+)
+
+// allowedAttachmentTypes are the image types accepted for upload, detected from the content rather than the request
+var allowedAttachmentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+}
+
+type AttachmentHandler struct {
+	db    *gorm.DB
+	store services.BlobStore
+}
+
+func NewAttachmentHandler(db *gorm.DB, store services.BlobStore) *AttachmentHandler {
+	return &AttachmentHandler{db: db, store: store}
+}
+
+// GetExcuseAttachments godoc
+// @Summary List excuse photos
+// @Tags attachments
+// @Produce json
+// @Param id path string true "Excuse ID" format:uuid
+// @Success 200 {object} GetAttachmentsResponse
+// @Failure 400 {object} ExcuseValidationErrorResponse
+// @Failure 401 {object} AttachmentUnauthorizedResponse
+// @Failure 404 {object} ExcuseNotFoundResponse
+// @Failure 500 {object} AttachmentFetchErrorResponse
+// @Security BearerAuth
+// @Router /excuses/{id}/attachments [get]
+func (h *AttachmentHandler) GetExcuseAttachments(c *gin.Context) {
+	excuse, ok := h.findExcuse(c)
+	if !ok {
+		return
+	}
+
+	var attachments []models.ExcuseAttachment
+	if err := h.db.Where("excuse_id = ?", excuse.ID).Order("created_at asc").Find(&attachments).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "写真の取得に失敗しました"})
+		return
+	}
+
+	res := GetAttachmentsResponse{Attachments: make([]AttachmentResponse, len(attachments))}
+	for i, a := range attachments {
+		res.Attachments[i] = mapToAttachmentResponse(a)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// PostExcuseAttachments godoc
+// @Summary Attach photo to excuse
+// @Description Upload a JPEG or PNG photo (max 10MB, 4 per excuse). A thumbnail is generated. The photos of all the user's excuses, trashed ones included, count toward the plan's maxStorageBytes (403 when exceeded).
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Excuse ID" format:uuid
+// @Param file formData file true "JPEG or PNG image"
+// @Success 201 {object} AttachmentResponse
+// @Failure 400 {object} AttachmentValidationErrorResponse
+// @Failure 401 {object} AttachmentUnauthorizedResponse
+// @Failure 403 {object} AttachmentQuotaExceededResponse
+// @Failure 404 {object} ExcuseNotFoundResponse
+// @Failure 500 {object} AttachmentSaveErrorResponse
+// @Security BearerAuth
+// @Router /excuses/{id}/attachments [post]
+func (h *AttachmentHandler) PostExcuseAttachments(c *gin.Context) {
+	excuse, ok := h.findExcuse(c)
+	if !ok {
+		return
+	}
+
+	entitlementsInterface, exists := c.Get("entitlements")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "プラン情報の取得に失敗しました"})
+		return
+	}
+	entitlements := entitlementsInterface.(services.Entitlements)
+
+	limitMultipartBody(c, maxAttachmentSize)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		if isBodyTooLarge(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルサイズが大きすぎます"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	if fileHeader.Size > maxAttachmentSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルサイズが大きすぎます"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルを読み込めませんでした"})
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxAttachmentSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルを読み込めませんでした"})
+		return
+	}
+	if len(data) > maxAttachmentSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルサイズが大きすぎます"})
+		return
+	}
+
+	contentType := http.DetectContentType(data)
+	if !allowedAttachmentTypes[contentType] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JPEGかPNGの画像を選んでください"})
+		return
+	}
+	thumbnail, width, height, err := services.MakeThumbnail(data, thumbnailMaxSide)
+	if err != nil {
+		if errors.Is(err, services.ErrImageTooLarge) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "画像の解像度が大きすぎます"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "JPEGかPNGの画像を選んでください"})
+		return
+	}
+
+	var count int64
+	if err := h.db.Model(&models.ExcuseAttachment{}).Where("excuse_id = ?", excuse.ID).Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "写真の保存に失敗しました"})
+		return
+	}
+	if count >= maxAttachmentsPerEntry {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("1つの言い訳に添付できる写真は%d枚までです", maxAttachmentsPerEntry)})
+		return
+	}
+
+	var used int64
+	err = h.db.Model(&models.ExcuseAttachment{}).
+		Select("COALESCE(SUM(size + thumbnail_size), 0)").
+		Where("user_id = ?", excuse.UserID).
+		Scan(&used).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "写真の保存に失敗しました"})
+		return
+	}
+	if used+int64(len(data)+len(thumbnail)) > entitlements.MaxStorageBytes {
+		c.JSON(http.StatusForbidden, gin.H{"error": "プランの保存容量を超えています"})
+		return
+	}
+
+	id := uuid.New()
+	attachment := models.ExcuseAttachment{
+		ID:            id,
+		UserID:        excuse.UserID,
+		ExcuseID:      excuse.ID,
+		ContentType:   contentType,
+		Size:          int64(len(data)),
+		ThumbnailSize: int64(len(thumbnail)),
+		Width:         width,
+		Height:        height,
+		BlobKey:       fmt.Sprintf("attachments/%s/original", id),
+		ThumbnailKey:  fmt.Sprintf("attachments/%s/thumbnail.jpg", id),
+	}
+
+	ctx := c.Request.Context()
+	err = h.store.Put(ctx, attachment.BlobKey, data, contentType)
+	if err == nil {
+		err = h.store.Put(ctx, attachment.ThumbnailKey, thumbnail, "image/jpeg")
+	}
+	if err == nil {
+		err = h.db.Create(&attachment).Error
+	}
+	if err != nil {
+		// Nothing refers to the blobs yet
+		services.DeleteAttachmentBlobs(ctx, h.store, attachment)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "写真の保存に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapToAttachmentResponse(attachment))
+}
+
+// GetAttachment godoc
+// @Summary Download photo
+// @Tags attachments
+// @Produce image/jpeg,image/png
+// @Param id path string true "Attachment ID" format:uuid
+// @Success 200 {file} file "Image"
+// @Failure 400 {object} ExcuseValidationErrorResponse
+// @Failure 401 {object} AttachmentUnauthorizedResponse
+// @Failure 404 {object} AttachmentNotFoundResponse
+// @Failure 500 {object} AttachmentFetchErrorResponse
+// @Security BearerAuth
+// @Router /attachments/{id} [get]
+func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
+	attachment, ok := h.findAttachment(c)
+	if !ok {
+		return
+	}
+	h.serveBlob(c, attachment.BlobKey, attachment.ContentType)
+}
+
+// GetAttachmentThumbnail godoc
+// @Summary Download photo thumbnail
+// @Description JPEG whose longer side is at most 320px.
+// @Tags attachments
+// @Produce image/jpeg
+// @Param id path string true "Attachment ID" format:uuid
+// @Success 200 {file} file "Image"
+// @Failure 400 {object} ExcuseValidationErrorResponse
+// @Failure 401 {object} AttachmentUnauthorizedResponse
+// @Failure 404 {object} AttachmentNotFoundResponse
+// @Failure 500 {object} AttachmentFetchErrorResponse
+// @Security BearerAuth
+// @Router /attachments/{id}/thumbnail [get]
+func (h *AttachmentHandler) GetAttachmentThumbnail(c *gin.Context) {
+	attachment, ok := h.findAttachment(c)
+	if !ok {
+		return
+	}
+	h.serveBlob(c, attachment.ThumbnailKey, "image/jpeg")
+}
+
+// DeleteAttachment godoc
+// @Summary Delete photo
+// @Description Delete the photo and its thumbnail right away. Photos of deleted excuses are removed when the excuse is purged from the trash.
+// @Tags attachments
+// @Param id path string true "Attachment ID" format:uuid
+// @Success 204 "No Content"
+// @Failure 400 {object} ExcuseValidationErrorResponse
+// @Failure 401 {object} AttachmentUnauthorizedResponse
+// @Failure 404 {object} AttachmentNotFoundResponse
+// @Failure 500 {object} AttachmentSaveErrorResponse
+// @Security BearerAuth
+// @Router /attachments/{id} [delete]
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	attachment, ok := h.findAttachment(c)
+	if !ok {
+		return
+	}
+
+	if err := services.DeleteAttachmentBlobs(c.Request.Context(), h.store, attachment); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "写真の削除に失敗しました"})
+		return
+	}
+	if err := h.db.Delete(&attachment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "写真の削除に失敗しました"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AttachmentHandler) findExcuse(c *gin.Context) (models.ExcuseEntry, bool) {
+	var excuse models.ExcuseEntry
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return excuse, false
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return excuse, false
+	}
+
+	if err := h.db.First(&excuse, "id = ? AND user_id = ?", id, userIDStr.(string)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "言い訳が見つかりません"})
+			return excuse, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の取得に失敗しました"})
+		return excuse, false
+	}
+	return excuse, true
+}
+
+func (h *AttachmentHandler) findAttachment(c *gin.Context) (models.ExcuseAttachment, bool) {
+	var attachment models.ExcuseAttachment
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return attachment, false
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return attachment, false
+	}
+
+	if err := h.db.First(&attachment, "id = ? AND user_id = ?", id, userIDStr.(string)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "写真が見つかりません"})
+			return attachment, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "写真の取得に失敗しました"})
+		return attachment, false
+	}
+	return attachment, true
+}
+
+func (h *AttachmentHandler) serveBlob(c *gin.Context, key, contentType string) {
+	data, err := h.store.Get(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, services.ErrBlobNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "写真が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "写真の取得に失敗しました"})
+		return
+	}
+	// The content of an attachment never changes
+	c.Header("Cache-Control", "private, max-age=31536000, immutable")
+	c.Data(http.StatusOK, contentType, data)
+}
+
+func mapToAttachmentResponse(a models.ExcuseAttachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:           a.ID,
+		ExcuseID:     a.ExcuseID,
+		ContentType:  a.ContentType,
+		Size:         a.Size,
+		Width:        a.Width,
+		Height:       a.Height,
+		URL:          fmt.Sprintf("/api/v1/attachments/%s", a.ID),
+		ThumbnailURL: fmt.Sprintf("/api/v1/attachments/%s/thumbnail", a.ID),
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+// limitMultipartBody caps the request body at one file of maxFileSize, so that c.FormFile stops reading
+// an oversized upload instead of spooling all of it to disk.
+func limitMultipartBody(c *gin.Context, maxFileSize int64) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxFileSize+multipartOverhead)
+}
+
+// isBodyTooLarge reports whether err comes from reading past the limit of limitMultipartBody.
+func isBodyTooLarge(err error) bool {
+	var tooLarge *http.MaxBytesError
+	return errors.As(err, &tooLarge)
+}
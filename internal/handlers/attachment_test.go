@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcuseAttachments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	store, err := services.NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+	handler := NewAttachmentHandler(db, store)
+
+	userID := "auth0|test"
+	goal := models.Goal{UserID: userID, Title: "Run"}
+	db.Create(&goal)
+	excuse := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: models.Date(testToday()), ExcuseText: "Cat on the keyboard"}
+	db.Create(&excuse)
+
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 640, 480)))
+	photo := buf.Bytes()
+
+	entitlements := services.Entitlements{MaxStorageBytes: 50 << 20}
+	call := func(handle gin.HandlerFunc, method, id string, file []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		contentType := ""
+		if file != nil {
+			mw := multipart.NewWriter(&body)
+			part, _ := mw.CreateFormFile("file", "photo.png")
+			part.Write(file)
+			mw.Close()
+			contentType = mw.FormDataContentType()
+		}
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", entitlements)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		c.Request, _ = http.NewRequest(method, "/", &body)
+		if contentType != "" {
+			c.Request.Header.Set("Content-Type", contentType)
+		}
+		handle(c)
+		// c.Status alone does not reach the recorder
+		c.Writer.WriteHeaderNow()
+		return w
+	}
+
+	var attachment AttachmentResponse
+	t.Run("Upload", func(t *testing.T) {
+		w := call(handler.PostExcuseAttachments, "POST", excuse.ID.String(), photo)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		json.Unmarshal(w.Body.Bytes(), &attachment)
+		assert.Equal(t, "image/png", attachment.ContentType)
+		assert.Equal(t, 640, attachment.Width)
+		assert.Equal(t, 480, attachment.Height)
+
+		w = call(handler.GetExcuseAttachments, "GET", excuse.ID.String(), nil)
+		var list GetAttachmentsResponse
+		json.Unmarshal(w.Body.Bytes(), &list)
+		assert.Len(t, list.Attachments, 1)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		w := call(handler.GetAttachment, "GET", attachment.ID.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, photo, w.Body.Bytes())
+
+		w = call(handler.GetAttachmentThumbnail, "GET", attachment.ID.String(), nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+		cfg, _, err := image.DecodeConfig(w.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, 320, cfg.Width)
+		assert.Equal(t, 240, cfg.Height)
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		w := call(handler.PostExcuseAttachments, "POST", excuse.ID.String(), []byte("GIF89a not really"))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("TooLarge", func(t *testing.T) {
+		w := call(handler.PostExcuseAttachments, "POST", excuse.ID.String(), make([]byte, maxAttachmentSize+multipartOverhead))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "ファイルサイズが大きすぎます")
+	})
+
+	t.Run("QuotaExceeded", func(t *testing.T) {
+		entitlements.MaxStorageBytes = attachment.Size + 1
+		defer func() { entitlements.MaxStorageBytes = 50 << 20 }()
+		w := call(handler.PostExcuseAttachments, "POST", excuse.ID.String(), photo)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("OtherUsersExcuse", func(t *testing.T) {
+		other := models.ExcuseEntry{UserID: "auth0|other", GoalID: goal.ID, Date: models.Date(testToday()), ExcuseText: "Mine"}
+		db.Create(&other)
+		w := call(handler.PostExcuseAttachments, "POST", other.ID.String(), photo)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		w := call(handler.DeleteAttachment, "DELETE", attachment.ID.String(), nil)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		w = call(handler.GetAttachment, "GET", attachment.ID.String(), nil)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("OrphanCleanup", func(t *testing.T) {
+		w := call(handler.PostExcuseAttachments, "POST", excuse.ID.String(), photo)
+		json.Unmarshal(w.Body.Bytes(), &attachment)
+
+		// Photos of excuses in the trash are kept
+		db.Delete(&excuse)
+		cleaner := services.NewAttachmentCleaner(db, store)
+		assert.NoError(t, cleaner.DeleteOrphans(t.Context()))
+		var count int64
+		db.Model(&models.ExcuseAttachment{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+
+		db.Unscoped().Delete(&excuse)
+		assert.NoError(t, cleaner.DeleteOrphans(t.Context()))
+		db.Model(&models.ExcuseAttachment{}).Count(&count)
+		assert.Equal(t, int64(0), count)
+		_, err := store.Get(t.Context(), "attachments/"+attachment.ID.String()+"/original")
+		assert.ErrorIs(t, err, services.ErrBlobNotFound)
+	})
+}
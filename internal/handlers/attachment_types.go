@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AttachmentResponse struct {
+	ID           uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	ExcuseID     uuid.UUID `json:"excuseId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ContentType  string    `json:"contentType" example:"image/jpeg"`
+	Size         int64     `json:"size" example:"482133"`
+	Width        int       `json:"width" example:"1920"`
+	Height       int       `json:"height" example:"1440"`
+	URL          string    `json:"url" example:"/api/v1/attachments/550e8400-e29b-41d4-a716-446655440003"`
+	ThumbnailURL string    `json:"thumbnailUrl" example:"/api/v1/attachments/550e8400-e29b-41d4-a716-446655440003/thumbnail"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+type GetAttachmentsResponse struct {
+	Attachments []AttachmentResponse `json:"attachments"`
+}
+
+type AttachmentValidationErrorResponse struct {
+	Error string `json:"error" example:"JPEGかPNGの画像を選んでください"`
+}
+
+type AttachmentQuotaExceededResponse struct {
+	Error string `json:"error" example:"プランの保存容量を超えています"`
+}
+
+type AttachmentNotFoundResponse struct {
+	Error string `json:"error" example:"写真が見つかりません"`
+}
+
+type AttachmentUnauthorizedResponse struct {
+	Error string `json:"error" example:"認証されていません"`
+}
+
+type AttachmentFetchErrorResponse struct {
+	Error string `json:"error" example:"写真の取得に失敗しました"`
+}
+
+type AttachmentSaveErrorResponse struct {
+	Error string `json:"error" example:"写真の保存に失敗しました"`
+}
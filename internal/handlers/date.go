@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const dateLayout = "2006-01-02"
+
+var errInvalidDate = errors.New("date must be a valid YYYY-MM-DD")
+
+// Date is a calendar date (YYYY-MM-DD). Decoding fails with errInvalidDate
+// for any other format or for days that do not exist, such as 2023-02-30.
+type Date string
+
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return errInvalidDate
+	}
+	if !isValidDate(s) {
+		return errInvalidDate
+	}
+	*d = Date(s)
+	return nil
+}
+
+func isValidDate(s string) bool {
+	_, err := time.Parse(dateLayout, s)
+	return err == nil
+}
+
+// bindErrorMessage returns the message for a request body that failed to bind.
+func bindErrorMessage(err error) string {
+	if errors.Is(err, errInvalidDate) {
+		return "日付はYYYY-MM-DD形式の正しい日付で指定してください"
+	}
+	return "入力内容が正しくありません"
+}
+
+// checkExcuseDate reports whether an excuse for goal may be written on date.
+// Archived goals take no excuses, and neither do days outside the goal's start and end dates or days on which it is paused.
+// Dates after the user's today or before the day the goal was created are rejected,
+// as are dates outside the plan's retention window. On rejection it returns the status and message to respond with.
+func checkExcuseDate(c *gin.Context, db *gorm.DB, goal models.Goal, date Date, entitlements services.Entitlements) (bool, int, string) {
+	if goal.ArchivedAt != nil {
+		return false, http.StatusConflict, "アーカイブした目標には言い訳を保存できません"
+	}
+	if (goal.StartDate != nil && models.Date(date) < *goal.StartDate) || (goal.EndDate != nil && models.Date(date) > *goal.EndDate) {
+		return false, http.StatusBadRequest, "目標の期間外の日付には保存できません"
+	}
+
+	loc := userLocation(c)
+	today := time.Now().In(loc)
+
+	// YYYY-MM-DD strings compare in date order
+	if string(date) > today.Format(dateLayout) {
+		return false, http.StatusBadRequest, "未来の日付には保存できません"
+	}
+	if string(date) < goal.CreatedAt.In(loc).Format(dateLayout) {
+		return false, http.StatusBadRequest, "目標の作成日より前の日付には保存できません"
+	}
+	if entitlements.LogRetentionDays != nil {
+		days := *entitlements.LogRetentionDays
+		if string(date) < today.AddDate(0, 0, -days).Format(dateLayout) {
+			return false, http.StatusForbidden, fmt.Sprintf("現在のプランでは%d日より前の日付には保存できません", days)
+		}
+	}
+	paused, err := isPaused(db, goal, string(date))
+	if err != nil {
+		return false, http.StatusInternalServerError, "休止期間の取得に失敗しました"
+	}
+	if paused {
+		return false, http.StatusConflict, "休止中の日には言い訳を保存できません"
+	}
+	return true, 0, ""
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"what-went-wrong-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Registering more devices than this evicts the least recently seen ones
+const maxDevicesPerUser = 10
+
+type DeviceHandler struct {
+	db *gorm.DB
+}
+
+func NewDeviceHandler(db *gorm.DB) *DeviceHandler {
+	return &DeviceHandler{db: db}
+}
+
+// GetMeDevices godoc
+// @Summary List devices
+// @Description List the devices registered for push notifications by the current user.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Success 200 {object} GetDevicesResponse
+// @Failure 401 {object} DeviceUnauthorizedResponse
+// @Failure 500 {object} DeviceFetchErrorResponse
+// @Security BearerAuth
+// @Router /me/devices [get]
+func (h *DeviceHandler) GetMeDevices(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var devices []models.Device
+	if err := h.db.Where("user_id = ?", userID).Order("last_seen_at desc").Find(&devices).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "デバイスの取得に失敗しました"})
+		return
+	}
+
+	res := GetDevicesResponse{Devices: make([]DeviceResponse, len(devices))}
+	for i, d := range devices {
+		res.Devices[i] = mapToDeviceResponse(d)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// PostMeDevices godoc
+// @Summary Register device
+// @Description Register a push token for the current user. Registering a known token refreshes it (and moves it from another account). When more than 10 devices are registered, the least recently seen ones are removed.
+// @Tags devices
+// @Accept json
+// @Produce json
+// @Param request body RegisterDeviceRequest true "Request body"
+// @Success 201 {object} DeviceResponse
+// @Success 200 {object} DeviceResponse "Already registered token refreshed"
+// @Failure 400 {object} DeviceValidationErrorResponse
+// @Failure 401 {object} DeviceUnauthorizedResponse
+// @Failure 500 {object} DeviceRegisterErrorResponse
+// @Security BearerAuth
+// @Router /me/devices [post]
+func (h *DeviceHandler) PostMeDevices(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var req RegisterDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+
+	var device models.Device
+	created := false
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.First(&device, "push_token = ?", req.PushToken).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		created = errors.Is(err, gorm.ErrRecordNotFound)
+
+		// The same token may come from another account after a re-login on the same phone
+		device.UserID = userID
+		device.Platform = req.Platform
+		device.PushToken = req.PushToken
+		device.AppVersion = req.AppVersion
+		device.Locale = req.Locale
+		device.LastSeenAt = time.Now()
+		if err := tx.Save(&device).Error; err != nil {
+			return err
+		}
+
+		// Enforce the cap by evicting the least recently seen devices
+		var stale []models.Device
+		if err := tx.Where("user_id = ?", userID).Order("last_seen_at desc").Offset(maxDevicesPerUser).Find(&stale).Error; err != nil {
+			return err
+		}
+		if len(stale) > 0 {
+			return tx.Delete(&stale).Error
+		}
+		return nil
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "デバイスの登録に失敗しました"})
+		return
+	}
+
+	if created {
+		c.JSON(http.StatusCreated, mapToDeviceResponse(device))
+		return
+	}
+	c.JSON(http.StatusOK, mapToDeviceResponse(device))
+}
+
+// DeleteMeDevice godoc
+// @Summary Unregister device
+// @Tags devices
+// @Param id path string true "Device ID" format:uuid
+// @Success 204 "No Content"
+// @Failure 400 {object} DeviceValidationErrorResponse
+// @Failure 401 {object} DeviceUnauthorizedResponse
+// @Failure 404 {object} DeviceNotFoundResponse
+// @Failure 500 {object} DeviceDeleteErrorResponse
+// @Security BearerAuth
+// @Router /me/devices/{id} [delete]
+func (h *DeviceHandler) DeleteMeDevice(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	result := h.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.Device{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "デバイスの削除に失敗しました"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "デバイスが見つかりません"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func mapToDeviceResponse(d models.Device) DeviceResponse {
+	return DeviceResponse{
+		ID:         d.ID.String(),
+		Platform:   d.Platform,
+		PushToken:  d.PushToken,
+		AppVersion: d.AppVersion,
+		Locale:     d.Locale,
+		LastSeenAt: d.LastSeenAt,
+		CreatedAt:  d.CreatedAt,
+	}
+}
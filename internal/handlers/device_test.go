@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostMeDevices(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	register := func(handler *DeviceHandler, userID string, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Request, _ = http.NewRequest("POST", "/me/devices", strings.NewReader(body))
+		handler.PostMeDevices(c)
+		return w
+	}
+
+	t.Run("RegisterAndRefresh", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewDeviceHandler(db)
+
+		w := register(handler, "auth0|test", `{"platform": "ios", "pushToken": "token-1", "appVersion": "1.0.0"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		w = register(handler, "auth0|test", `{"platform": "ios", "pushToken": "token-1", "appVersion": "1.1.0"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp DeviceResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, "1.1.0", resp.AppVersion)
+
+		var count int64
+		db.Model(&models.Device{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("TokenMovesToNewAccount", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewDeviceHandler(db)
+
+		register(handler, "auth0|old", `{"platform": "android", "pushToken": "token-1"}`)
+		register(handler, "auth0|new", `{"platform": "android", "pushToken": "token-1"}`)
+
+		var device models.Device
+		db.First(&device, "push_token = ?", "token-1")
+		assert.Equal(t, "auth0|new", device.UserID)
+	})
+
+	t.Run("InvalidPlatform", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewDeviceHandler(db)
+
+		w := register(handler, "auth0|test", `{"platform": "windows", "pushToken": "token-1"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("CapEvictsLeastRecentlySeen", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewDeviceHandler(db)
+
+		userID := "auth0|test"
+		for i := 0; i < maxDevicesPerUser; i++ {
+			db.Create(&models.Device{
+				UserID:     userID,
+				Platform:   "ios",
+				PushToken:  fmt.Sprintf("old-%d", i),
+				LastSeenAt: time.Now().Add(-time.Duration(i+1) * time.Hour),
+			})
+		}
+
+		w := register(handler, userID, `{"platform": "ios", "pushToken": "new"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		var count int64
+		db.Model(&models.Device{}).Where("user_id = ?", userID).Count(&count)
+		assert.Equal(t, int64(maxDevicesPerUser), count)
+
+		// The oldest one is gone
+		var oldest int64
+		db.Model(&models.Device{}).Where("push_token = ?", fmt.Sprintf("old-%d", maxDevicesPerUser-1)).Count(&oldest)
+		assert.Equal(t, int64(0), oldest)
+	})
+}
+
+func TestDeleteMeDevice(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewDeviceHandler(db)
+
+	device := models.Device{UserID: "auth0|test", Platform: "ios", PushToken: "token-1"}
+	db.Create(&device)
+
+	tests := []struct {
+		name           string
+		userID         string
+		expectedStatus int
+	}{
+		{name: "OtherUser", userID: "auth0|other", expectedStatus: http.StatusNotFound},
+		{name: "Owner", userID: "auth0|test", expectedStatus: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("userID", tt.userID)
+			c.Params = gin.Params{{Key: "id", Value: device.ID.String()}}
+			c.Request, _ = http.NewRequest("DELETE", "/me/devices/"+device.ID.String(), nil)
+
+			handler.DeleteMeDevice(c)
+			// c.Status alone does not reach the recorder
+			c.Writer.WriteHeaderNow()
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import "time"
+
+type RegisterDeviceRequest struct {
+	Platform   string `json:"platform" binding:"required,oneof=ios android" example:"ios"`
+	PushToken  string `json:"pushToken" binding:"required,max=512" example:"740f4707bebcf74f9b7c25d48e3358945f6aa01da5ddb387462c7eaf61bb78ad"`
+	AppVersion string `json:"appVersion" binding:"max=50" example:"1.2.0"`
+	Locale     string `json:"locale" binding:"max=50" example:"ja-JP"`
+}
+
+type DeviceResponse struct {
+	ID         string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Platform   string    `json:"platform" example:"ios"`
+	PushToken  string    `json:"pushToken" example:"740f4707bebcf74f9b7c25d48e3358945f6aa01da5ddb387462c7eaf61bb78ad"`
+	AppVersion string    `json:"appVersion" example:"1.2.0"`
+	Locale     string    `json:"locale" example:"ja-JP"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type GetDevicesResponse struct {
+	Devices []DeviceResponse `json:"devices"`
+}
+
+type DeviceUnauthorizedResponse struct {
+	Error string `json:"error" example:"認証されていません"`
+}
+
+type DeviceValidationErrorResponse struct {
+	Error string `json:"error" example:"入力内容が正しくありません"`
+}
+
+type DeviceNotFoundResponse struct {
+	Error string `json:"error" example:"デバイスが見つかりません"`
+}
+
+type DeviceFetchErrorResponse struct {
+	Error string `json:"error" example:"デバイスの取得に失敗しました"`
+}
+
+type DeviceRegisterErrorResponse struct {
+	Error string `json:"error" example:"デバイスの登録に失敗しました"`
+}
+
+type DeviceDeleteErrorResponse struct {
+	Error string `json:"error" example:"デバイスの削除に失敗しました"`
+}
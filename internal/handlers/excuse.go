@@ -2,22 +2,29 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 	"what-went-wrong-api/internal/models"
 	"what-went-wrong-api/internal/services"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
+var errDailyExcuseLimitReached = errors.New("daily excuse limit reached")
+
 type ExcuseHandler struct {
-	db *gorm.DB
+	db        *gorm.DB
+	moderator services.Moderator
 }
 
-func NewExcuseHandler(db *gorm.DB) *ExcuseHandler {
-	return &ExcuseHandler{db: db}
+func NewExcuseHandler(db *gorm.DB, moderator services.Moderator) *ExcuseHandler {
+	return &ExcuseHandler{db: db, moderator: moderator}
 }
 
 // GetExcuses godoc
@@ -29,8 +36,10 @@ func NewExcuseHandler(db *gorm.DB) *ExcuseHandler {
 // @Param goal_id path string true "Goal ID"
 // @Param from query string false "From Date (YYYY-MM-DD)"
 // @Param to query string false "To Date (YYYY-MM-DD)"
+// @Param mood query int false "Only excuses with this mood (1-5)"
+// @Param tag query string false "Only excuses with this tag"
 // @Success 200 {object} GetExcusesResponse
-// @Failure 400 {object} ExcuseValidationErrorResponse "Invalid Goal ID"
+// @Failure 400 {object} ExcuseValidationErrorResponse "Invalid Goal ID or date"
 // @Failure 401 {object} ExcuseUnauthorizedResponse
 // @Failure 500 {object} ExcuseFetchErrorResponse
 // @Security BearerAuth
@@ -50,18 +59,129 @@ func (h *ExcuseHandler) GetExcuses(c *gin.Context) {
 		return
 	}
 
+	query, ok := excuseQuery(c, h.db, userID, goalID)
+	if !ok {
+		return
+	}
+	if mood := c.Query("mood"); mood != "" {
+		n, err := strconv.Atoi(mood)
+		if err != nil || n < 1 || n > 5 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+			return
+		}
+		query = query.Where("mood = ?", n)
+	}
+	if tag := c.Query("tag"); tag != "" {
+		query = query.Where("? = ANY(tags)", tag)
+	}
+
+	var excuses []models.ExcuseEntry
+	if err := query.Order("date desc, created_at desc").Find(&excuses).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の取得に失敗しました"})
+		return
+	}
+
+	res := GetExcusesResponse{Excuses: make([]ExcuseResponse, len(excuses))}
+	for i, e := range excuses {
+		res.Excuses[i] = mapToResponse(e)
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// GetGoalStats godoc
+// @Summary Get excuse stats for a goal
+// @Description Aggregate the goal's excuses: how many there are, the moods and the tags, most common first, to surface the most common reason for missing the goal. The same date filters and retention window as GET /goals/{goal_id}/excuses apply.
+// @Tags excuses
+// @Produce json
+// @Param goal_id path string true "Goal ID"
+// @Param from query string false "From Date (YYYY-MM-DD)"
+// @Param to query string false "To Date (YYYY-MM-DD)"
+// @Success 200 {object} GoalStatsResponse
+// @Failure 400 {object} ExcuseValidationErrorResponse "Invalid Goal ID or date"
+// @Failure 401 {object} ExcuseUnauthorizedResponse
+// @Failure 500 {object} ExcuseFetchErrorResponse
+// @Security BearerAuth
+// @Router /goals/{goal_id}/stats [get]
+func (h *ExcuseHandler) GetGoalStats(c *gin.Context) {
+	userIdStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIdStr.(string)
+
+	goalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+
+	query, ok := excuseQuery(c, h.db, userID, goalID)
+	if !ok {
+		return
+	}
+
+	var totals struct {
+		ExcuseCount int
+		AverageMood *float64
+	}
+	var moodCounts []MoodCount
+	var tagCounts []TagCount
+	err = query.Session(&gorm.Session{}).Select("COUNT(*) AS excuse_count, AVG(mood) AS average_mood").Scan(&totals).Error
+	if err == nil {
+		err = query.Session(&gorm.Session{}).
+			Select("mood, COUNT(*) AS count").Where("mood IS NOT NULL").
+			Group("mood").Order("mood asc").
+			Scan(&moodCounts).Error
+	}
+	if err == nil {
+		err = h.db.Table("(?) AS excuses", query.Session(&gorm.Session{}).Select("unnest(tags) AS tag")).
+			Select("tag, COUNT(*) AS count").
+			Group("tag").Order("count desc, tag asc").
+			Scan(&tagCounts).Error
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の取得に失敗しました"})
+		return
+	}
+
+	res := GoalStatsResponse{
+		ExcuseCount: totals.ExcuseCount,
+		AverageMood: totals.AverageMood,
+		MoodCounts:  moodCounts,
+		TagCounts:   tagCounts,
+	}
+	if res.MoodCounts == nil {
+		res.MoodCounts = []MoodCount{}
+	}
+	if res.TagCounts == nil {
+		res.TagCounts = []TagCount{}
+	}
+	if len(res.TagCounts) > 0 {
+		res.MostCommonTag = &res.TagCounts[0].Tag
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// excuseQuery scopes the excuses of the goal with filterExcuseDates.
+func excuseQuery(c *gin.Context, db *gorm.DB, userID string, goalID uuid.UUID) (*gorm.DB, bool) {
+	return filterExcuseDates(c, db.Model(&models.ExcuseEntry{}).Where("user_id = ? AND goal_id = ?", userID, goalID))
+}
+
+// filterExcuseDates narrows an excuse query to the plan's retention window and the from and to query parameters.
+// When a parameter is invalid it responds with 400 and returns false.
+func filterExcuseDates(c *gin.Context, query *gorm.DB) (*gorm.DB, bool) {
 	entitlementsInterface, exists := c.Get("entitlements")
 	if !exists {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "プラン情報の取得に失敗しました"})
-		return
+		return nil, false
 	}
 	entitlements := entitlementsInterface.(services.Entitlements)
 
-	query := h.db.Model(&models.ExcuseEntry{}).Where("user_id = ? AND goal_id = ?", userID, goalID)
-
 	// Entitlement: logRetentionDays
 	if entitlements.LogRetentionDays != nil {
-		retentionDate := time.Now().AddDate(0, 0, -*entitlements.LogRetentionDays).Format("2006-01-02")
+		retentionDate := time.Now().In(userLocation(c)).AddDate(0, 0, -*entitlements.LogRetentionDays).Format(dateLayout)
 		// Force filter: date >= retentionDate
 		query = query.Where("date >= ?", retentionDate)
 	}
@@ -69,37 +189,26 @@ func (h *ExcuseHandler) GetExcuses(c *gin.Context) {
 	// Manual Filters (if they don't violate retention)
 	from := c.Query("from")
 	if from != "" {
+		if !isValidDate(from) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "日付はYYYY-MM-DD形式の正しい日付で指定してください"})
+			return nil, false
+		}
 		query = query.Where("date >= ?", from)
 	}
 	to := c.Query("to")
 	if to != "" {
-		query = query.Where("date <= ?", to)
-	}
-
-	var excuses []models.ExcuseEntry
-	if err := query.Order("date desc").Find(&excuses).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の取得に失敗しました"})
-		return
-	}
-
-	res := GetExcusesResponse{Excuses: make([]ExcuseResponse, len(excuses))}
-	for i, e := range excuses {
-		res.Excuses[i] = ExcuseResponse{
-			ID:         e.ID,
-			GoalID:     e.GoalID,
-			Date:       e.Date,
-			ExcuseText: e.ExcuseText,
-			TemplateID: e.TemplateID,
-			CreatedAt:  e.CreatedAt,
-			UpdatedAt:  e.UpdatedAt,
+		if !isValidDate(to) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "日付はYYYY-MM-DD形式の正しい日付で指定してください"})
+			return nil, false
 		}
+		query = query.Where("date <= ?", to)
 	}
-
-	c.JSON(http.StatusOK, res)
+	return query, true
 }
 
 // GetExcuseToday godoc
 // @Summary Get today's excuse for a goal
+// @Description "Today" is in the user's time zone. For a goal taking several excuses a day, the latest one.
 // @Tags excuses
 // @Accept json
 // @Produce json
@@ -126,9 +235,9 @@ func (h *ExcuseHandler) GetExcuseToday(c *gin.Context) {
 		return
 	}
 
-	today := time.Now().Format("2006-01-02")
+	today := userToday(c)
 	var excuse models.ExcuseEntry
-	if err := h.db.Where("user_id = ? AND goal_id = ? AND date = ?", userID, goalID, today).First(&excuse).Error; err != nil {
+	if err := h.db.Where("user_id = ? AND goal_id = ? AND date = ?", userID, goalID, today).Order("created_at desc").First(&excuse).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "本日の言い訳が見つかりません"})
 			return
@@ -137,20 +246,12 @@ func (h *ExcuseHandler) GetExcuseToday(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, ExcuseResponse{
-		ID:         excuse.ID,
-		GoalID:     excuse.GoalID,
-		Date:       excuse.Date,
-		ExcuseText: excuse.ExcuseText,
-		TemplateID: excuse.TemplateID,
-		CreatedAt:  excuse.CreatedAt,
-		UpdatedAt:  excuse.UpdatedAt,
-	})
+	c.JSON(http.StatusOK, mapToResponse(excuse))
 }
 
 // PostExcuse godoc
 // @Summary Create or update an excuse
-// @Description Upsert excuse for a date. Checks entitlement if using premium template.
+// @Description Upsert excuse for a date. When the day already has an excuse, its mood, tags and note are kept unless sent. For a goal whose maxExcusesPerDay is more than 1, a new excuse is added instead, up to that many a day (409 beyond). The date must be between the goal's creation day and the user's today, and within the plan's retention window (403 otherwise). Checks entitlement if using premium template. The text is moderated (rejected, masked or flagged for review).
 // @Tags excuses
 // @Accept json
 // @Produce json
@@ -160,6 +261,8 @@ func (h *ExcuseHandler) GetExcuseToday(c *gin.Context) {
 // @Failure 400 {object} ExcuseValidationErrorResponse
 // @Failure 401 {object} ExcuseUnauthorizedResponse
 // @Failure 403 {object} ExcuseForbiddenResponse
+// @Failure 404 {object} GoalNotFoundErrorResponse
+// @Failure 409 {object} ExcuseGoalArchivedResponse
 // @Failure 500 {object} ExcuseCreateErrorResponse
 // @Security BearerAuth
 // @Router /goals/{goal_id}/excuses [post]
@@ -175,13 +278,27 @@ func (h *ExcuseHandler) PostExcuse(c *gin.Context) {
 
 	var req CreateExcuseRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": bindErrorMessage(err)})
 		return
 	}
 
 	entitlementsInterface, _ := c.Get("entitlements")
 	entitlements := entitlementsInterface.(services.Entitlements)
 
+	var goal models.Goal
+	if err := h.db.First(&goal, "id = ? AND user_id = ?", goalID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "目標が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の作成に失敗しました"})
+		return
+	}
+	if ok, status, message := checkExcuseDate(c, h.db, goal, req.Date, entitlements); !ok {
+		c.JSON(status, gin.H{"error": message})
+		return
+	}
+
 	// Verify template if provided
 	if req.TemplateID != "" {
 		var tmpl models.ExcuseTemplate
@@ -195,38 +312,54 @@ func (h *ExcuseHandler) PostExcuse(c *gin.Context) {
 		}
 	}
 
-	// Upsert Logic
-	var excuse models.ExcuseEntry
-	err = h.db.Where("user_id = ? AND goal_id = ? AND date = ?", userID, goalID, req.Date).First(&excuse).Error
-	if err == nil {
-		// Update
-		excuse.ExcuseText = req.ExcuseText
-		if req.TemplateID != "" {
-			excuse.TemplateID = &req.TemplateID
-		} else {
-			excuse.TemplateID = nil
-		}
-		h.db.Save(&excuse)
-		c.JSON(http.StatusOK, mapToResponse(excuse))
-	} else if errors.Is(err, gorm.ErrRecordNotFound) {
-		// Create
-		excuse = models.ExcuseEntry{
-			UserID:     userID,
-			GoalID:     goalID,
-			Date:       req.Date,
-			ExcuseText: req.ExcuseText,
+	moderation, err := h.moderator.Moderate(req.ExcuseText)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の作成に失敗しました"})
+		return
+	}
+	if moderation.Rejected {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "不適切な表現が含まれています"})
+		return
+	}
+
+	excuse := models.ExcuseEntry{
+		UserID:     userID,
+		GoalID:     goalID,
+		Date:       models.Date(req.Date),
+		ExcuseText: moderation.Text,
+		Mood:       req.Mood,
+		Tags:       normalizeTags(req.Tags),
+		Note:       nilIfEmpty(req.Note),
+	}
+	if req.TemplateID != "" {
+		excuse.TemplateID = &req.TemplateID
+	}
+
+	var created bool
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		excuse, created, err = saveExcuseEntry(tx, goal, excuse)
+		if err != nil {
+			return err
 		}
-		if req.TemplateID != "" {
-			excuse.TemplateID = &req.TemplateID
+		if moderation.Flagged {
+			return enqueueModerationReview(tx, userID, "excuse", &excuse.ID, moderation)
 		}
-		if err := h.db.Create(&excuse).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の作成に失敗しました"})
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errDailyExcuseLimitReached) {
+			c.JSON(http.StatusConflict, gin.H{"error": dailyExcuseLimitMessage(goal)})
 			return
 		}
-		c.JSON(http.StatusCreated, mapToResponse(excuse))
-	} else {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の作成に失敗しました"})
+		return
 	}
+	if created {
+		c.JSON(http.StatusCreated, mapToResponse(excuse))
+		return
+	}
+	c.JSON(http.StatusOK, mapToResponse(excuse))
 }
 
 // PatchExcuse godoc
@@ -265,12 +398,27 @@ func (h *ExcuseHandler) PatchExcuse(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "言い訳が見つかりません"})
 		return
 	}
+	before := excuse
 
 	entitlementsInterface, _ := c.Get("entitlements")
 	entitlements := entitlementsInterface.(services.Entitlements)
 
+	var moderation *services.ModerationResult
 	if req.ExcuseText != "" {
-		excuse.ExcuseText = req.ExcuseText
+		moderation, err = h.moderator.Moderate(req.ExcuseText)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の更新に失敗しました"})
+			return
+		}
+		if moderation.Rejected {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "不適切な表現が含まれています"})
+			return
+		}
+		if moderation.Text != excuse.ExcuseText {
+			// The excuse is no longer the text the AI generated
+			excuse.AiGenerationID = nil
+		}
+		excuse.ExcuseText = moderation.Text
 	}
 
 	// If TemplateID is updated (checked if present in request via pointer usually, but here string empty assumes no change or unset?
@@ -288,8 +436,32 @@ func (h *ExcuseHandler) PatchExcuse(c *gin.Context) {
 		}
 		excuse.TemplateID = &req.TemplateID
 	}
+	if req.Mood != nil {
+		excuse.Mood = req.Mood
+		if *req.Mood == 0 {
+			excuse.Mood = nil
+		}
+	}
+	if req.Tags != nil {
+		excuse.Tags = normalizeTags(*req.Tags)
+	}
+	if req.Note != nil {
+		excuse.Note = nilIfEmpty(req.Note)
+	}
 
-	if err := h.db.Save(&excuse).Error; err != nil {
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := recordExcuseRevision(tx, before, excuse); err != nil {
+			return err
+		}
+		if err := tx.Save(&excuse).Error; err != nil {
+			return err
+		}
+		if moderation != nil && moderation.Flagged {
+			return enqueueModerationReview(tx, userID, "excuse", &excuse.ID, moderation)
+		}
+		return nil
+	})
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の更新に失敗しました"})
 		return
 	}
@@ -298,6 +470,7 @@ func (h *ExcuseHandler) PatchExcuse(c *gin.Context) {
 
 // DeleteExcuse godoc
 // @Summary Delete an excuse
+// @Description Move the excuse to the trash. It can be restored until it is purged.
 // @Tags excuses
 // @Param id path string true "Excuse ID"
 // @Success 204 "No Content"
@@ -317,27 +490,173 @@ func (h *ExcuseHandler) DeleteExcuse(c *gin.Context) {
 	userIdStr, _ := c.Get("userID")
 	userID := userIdStr.(string)
 
-	result := h.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.ExcuseEntry{})
-	if result.Error != nil {
+	var excuse models.ExcuseEntry
+	if err := h.db.First(&excuse, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "言い訳が見つかりません"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の削除に失敗しました"})
 		return
 	}
-	if result.RowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "言い訳が見つかりません"})
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		return deleteExcuseEntry(tx, excuse)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の削除に失敗しました"})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
 
+// saveExcuseEntry saves entry for its date. A goal taking one excuse a day overwrites the day's excuse
+// (see upsertExcuseEntry); other goals get a new excuse, or errDailyExcuseLimitReached at MaxExcusesPerDay.
+// The returned bool reports whether a new row was created.
+func saveExcuseEntry(db *gorm.DB, goal models.Goal, entry models.ExcuseEntry) (models.ExcuseEntry, bool, error) {
+	// Concurrent saves for the same day would pick the same slot. The per-user lock is the one
+	// the insert takes anyway, so taking it first cannot deadlock with other changes of the user.
+	if err := models.LockUserChanges(db, entry.UserID); err != nil {
+		return entry, false, err
+	}
+	if goal.MaxExcusesPerDay <= 1 {
+		return upsertExcuseEntry(db, entry)
+	}
+
+	var slots []int
+	err := db.Model(&models.ExcuseEntry{}).
+		Where("user_id = ? AND goal_id = ? AND date = ?", entry.UserID, entry.GoalID, entry.Date).
+		Pluck("slot", &slots).Error
+	if err != nil {
+		return entry, false, err
+	}
+	used := make(map[int]bool, len(slots))
+	for _, slot := range slots {
+		used[slot] = true
+	}
+	entry.Slot = -1
+	for slot := 0; slot < goal.MaxExcusesPerDay; slot++ {
+		if !used[slot] {
+			entry.Slot = slot
+			break
+		}
+	}
+	if entry.Slot < 0 {
+		return entry, false, errDailyExcuseLimitReached
+	}
+
+	if err := createExcuseEntry(db, &entry); err != nil {
+		return entry, false, err
+	}
+	return entry, true, nil
+}
+
+// upsertExcuseEntry saves entry as the excuse for its (user, goal, date),
+// overwriting an existing one, whose wording is kept as a revision. The mood, tags and note of an existing one
+// are kept unless entry sets them.
+// The returned bool reports whether a new row was created.
+func upsertExcuseEntry(db *gorm.DB, entry models.ExcuseEntry) (models.ExcuseEntry, bool, error) {
+	var excuse models.ExcuseEntry
+	err := db.Where("user_id = ? AND goal_id = ? AND date = ?", entry.UserID, entry.GoalID, entry.Date).Order("slot").First(&excuse).Error
+	if err == nil {
+		// Update
+		before := excuse
+		excuse.ExcuseText = entry.ExcuseText
+		excuse.TemplateID = entry.TemplateID
+		excuse.AiGenerationID = entry.AiGenerationID
+		if entry.Mood != nil {
+			excuse.Mood = entry.Mood
+		}
+		if entry.Tags != nil {
+			excuse.Tags = entry.Tags
+		}
+		if entry.Note != nil {
+			excuse.Note = entry.Note
+		}
+		if err := recordExcuseRevision(db, before, excuse); err != nil {
+			return excuse, false, err
+		}
+		if err := db.Save(&excuse).Error; err != nil {
+			return excuse, false, err
+		}
+		return excuse, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return excuse, false, err
+	}
+
+	// Create
+	entry.Slot = 0
+	if err := createExcuseEntry(db, &entry); err != nil {
+		return entry, false, err
+	}
+	return entry, true, nil
+}
+
+// createExcuseEntry inserts entry in its slot of the day.
+func createExcuseEntry(db *gorm.DB, entry *models.ExcuseEntry) error {
+	// A new excuse replaces one of the same slot in the trash
+	err := db.Unscoped().
+		Where("user_id = ? AND goal_id = ? AND date = ? AND slot = ? AND deleted_at IS NOT NULL", entry.UserID, entry.GoalID, entry.Date, entry.Slot).
+		Delete(&models.ExcuseEntry{}).Error
+	if err != nil {
+		return err
+	}
+	return db.Create(entry).Error
+}
+
+// deleteExcuseEntry moves the excuse to the trash, leaving a tombstone for delta sync.
+func deleteExcuseEntry(tx *gorm.DB, excuse models.ExcuseEntry) error {
+	if err := tx.Delete(&excuse).Error; err != nil {
+		return err
+	}
+	return tx.Create(&models.Tombstone{UserID: excuse.UserID, EntityType: "excuse", EntityID: excuse.ID}).Error
+}
+
+// maxDailyExcuseCount returns the largest number of excuses the goal has on a single day, not counting the trash.
+func maxDailyExcuseCount(db *gorm.DB, goalID uuid.UUID) (int, error) {
+	var count int
+	err := db.Raw("SELECT COALESCE(MAX(n), 0) FROM (SELECT COUNT(*) AS n FROM excuse_entries WHERE goal_id = ? AND deleted_at IS NULL GROUP BY date) AS daily", goalID).
+		Scan(&count).Error
+	return count, err
+}
+
+func dailyExcuseLimitMessage(goal models.Goal) string {
+	return fmt.Sprintf("この目標に1日に保存できる言い訳は%d件までです", goal.MaxExcusesPerDay)
+}
+
+// normalizeTags trims the tags and drops empty and duplicate ones, keeping the order. It returns nil for no tags.
+func normalizeTags(tags []string) pq.StringArray {
+	var res pq.StringArray
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		res = append(res, tag)
+	}
+	return res
+}
+
 func mapToResponse(e models.ExcuseEntry) ExcuseResponse {
+	tags := []string(e.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
 	return ExcuseResponse{
-		ID:         e.ID,
-		GoalID:     e.GoalID,
-		Date:       e.Date,
-		ExcuseText: e.ExcuseText,
-		TemplateID: e.TemplateID,
-		CreatedAt:  e.CreatedAt,
-		UpdatedAt:  e.UpdatedAt,
+		ID:             e.ID,
+		GoalID:         e.GoalID,
+		Date:           string(e.Date),
+		ExcuseText:     e.ExcuseText,
+		TemplateID:     e.TemplateID,
+		Mood:           e.Mood,
+		Tags:           tags,
+		Note:           e.Note,
+		AiGenerationID: e.AiGenerationID,
+		Version:        e.Version,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
 	}
 }
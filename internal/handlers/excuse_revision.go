@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ExcuseRevisionHandler struct {
+	db *gorm.DB
+}
+
+func NewExcuseRevisionHandler(db *gorm.DB) *ExcuseRevisionHandler {
+	return &ExcuseRevisionHandler{db: db}
+}
+
+// GetExcuseRevisions godoc
+// @Summary List earlier wordings of an excuse
+// @Description Newest first. Free plans only keep the revisions replaced within logRetentionDays.
+// @Tags excuses
+// @Produce json
+// @Param id path string true "Excuse ID" format:uuid
+// @Success 200 {object} GetExcuseRevisionsResponse
+// @Failure 400 {object} ExcuseValidationErrorResponse
+// @Failure 401 {object} ExcuseUnauthorizedResponse
+// @Failure 404 {object} ExcuseNotFoundResponse
+// @Failure 500 {object} ExcuseRevisionFetchErrorResponse
+// @Security BearerAuth
+// @Router /excuses/{id}/revisions [get]
+func (h *ExcuseRevisionHandler) GetExcuseRevisions(c *gin.Context) {
+	excuse, ok := h.findExcuse(c)
+	if !ok {
+		return
+	}
+
+	var revisions []models.ExcuseRevision
+	if err := h.revisionQuery(c, excuse.ID).Order("created_at desc").Find(&revisions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "履歴の取得に失敗しました"})
+		return
+	}
+
+	res := GetExcuseRevisionsResponse{Revisions: make([]ExcuseRevisionResponse, len(revisions))}
+	for i, r := range revisions {
+		res.Revisions[i] = mapToExcuseRevisionResponse(r)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// PostRestoreExcuseRevision godoc
+// @Summary Restore an earlier wording of an excuse
+// @Description Put the text and template of the revision back. The current wording is kept as a new revision, so a restore can be undone.
+// @Tags excuses
+// @Produce json
+// @Param id path string true "Excuse ID" format:uuid
+// @Param revisionId path string true "Revision ID" format:uuid
+// @Success 200 {object} ExcuseResponse
+// @Failure 400 {object} ExcuseValidationErrorResponse
+// @Failure 401 {object} ExcuseUnauthorizedResponse
+// @Failure 403 {object} ExcuseForbiddenResponse
+// @Failure 404 {object} ExcuseRevisionNotFoundResponse
+// @Failure 500 {object} ExcuseUpdateErrorResponse
+// @Security BearerAuth
+// @Router /excuses/{id}/revisions/{revisionId}/restore [post]
+func (h *ExcuseRevisionHandler) PostRestoreExcuseRevision(c *gin.Context) {
+	revisionID, err := uuid.Parse(c.Param("revisionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	excuse, ok := h.findExcuse(c)
+	if !ok {
+		return
+	}
+
+	var revision models.ExcuseRevision
+	if err := h.revisionQuery(c, excuse.ID).First(&revision, "id = ?", revisionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "履歴が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の更新に失敗しました"})
+		return
+	}
+
+	// The user may have left the premium plan since the template was picked
+	if revision.TemplateID != nil {
+		entitlementsInterface, _ := c.Get("entitlements")
+		entitlements := entitlementsInterface.(services.Entitlements)
+		var tmpl models.ExcuseTemplate
+		if err := h.db.First(&tmpl, "id = ?", *revision.TemplateID).Error; err == nil && tmpl.IsPremium && !entitlements.CanUsePremiumTemplates {
+			c.JSON(http.StatusForbidden, gin.H{"error": "プレミアムテンプレートを利用するにはプレミアムプランが必要です"})
+			return
+		}
+	}
+
+	before := excuse
+	excuse.ExcuseText = revision.ExcuseText
+	excuse.TemplateID = revision.TemplateID
+	excuse.AiGenerationID = revision.AiGenerationID
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := recordExcuseRevision(tx, before, excuse); err != nil {
+			return err
+		}
+		return tx.Save(&excuse).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の更新に失敗しました"})
+		return
+	}
+	c.JSON(http.StatusOK, mapToResponse(excuse))
+}
+
+func (h *ExcuseRevisionHandler) findExcuse(c *gin.Context) (models.ExcuseEntry, bool) {
+	var excuse models.ExcuseEntry
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return excuse, false
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return excuse, false
+	}
+
+	if err := h.db.First(&excuse, "id = ? AND user_id = ?", id, userIDStr.(string)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "言い訳が見つかりません"})
+			return excuse, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の取得に失敗しました"})
+		return excuse, false
+	}
+	return excuse, true
+}
+
+// revisionQuery selects the revisions of the excuse within the log retention of the user's plan.
+// Older ones are deleted by ExcuseRevisionService but may linger until its next run.
+func (h *ExcuseRevisionHandler) revisionQuery(c *gin.Context, excuseID uuid.UUID) *gorm.DB {
+	query := h.db.Where("excuse_id = ?", excuseID)
+	entitlementsInterface, _ := c.Get("entitlements")
+	entitlements := entitlementsInterface.(services.Entitlements)
+	if entitlements.LogRetentionDays != nil {
+		query = query.Where("created_at >= ?", time.Now().AddDate(0, 0, -*entitlements.LogRetentionDays))
+	}
+	return query
+}
+
+// recordExcuseRevision keeps the wording of before as a revision when after changes its text or template.
+func recordExcuseRevision(tx *gorm.DB, before, after models.ExcuseEntry) error {
+	if before.ExcuseText == after.ExcuseText && equalStringPtr(before.TemplateID, after.TemplateID) {
+		return nil
+	}
+	return tx.Create(&models.ExcuseRevision{
+		UserID:         before.UserID,
+		ExcuseID:       before.ID,
+		ExcuseText:     before.ExcuseText,
+		TemplateID:     before.TemplateID,
+		AiGenerationID: before.AiGenerationID,
+		WrittenAt:      before.UpdatedAt,
+	}).Error
+}
+
+func mapToExcuseRevisionResponse(r models.ExcuseRevision) ExcuseRevisionResponse {
+	return ExcuseRevisionResponse{
+		ID:             r.ID,
+		ExcuseID:       r.ExcuseID,
+		ExcuseText:     r.ExcuseText,
+		TemplateID:     r.TemplateID,
+		AiGenerationID: r.AiGenerationID,
+		WrittenAt:      r.WrittenAt,
+		ReplacedAt:     r.CreatedAt,
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExcuseRevisions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	excuseHandler := NewExcuseHandler(db, services.NewRuleModerator(nil))
+	handler := NewExcuseRevisionHandler(db)
+
+	userID := "auth0|test"
+	goal := models.Goal{UserID: userID, Title: "Run"}
+	db.Create(&goal)
+
+	entitlements := services.Entitlements{}
+	call := func(handle gin.HandlerFunc, method string, params gin.Params, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", entitlements)
+		c.Params = params
+		c.Request, _ = http.NewRequest(method, "/", strings.NewReader(body))
+		handle(c)
+		return w
+	}
+	revisions := func(excuseID string) []ExcuseRevisionResponse {
+		w := call(handler.GetExcuseRevisions, "GET", gin.Params{{Key: "id", Value: excuseID}}, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res GetExcuseRevisionsResponse
+		json.Unmarshal(w.Body.Bytes(), &res)
+		return res.Revisions
+	}
+
+	goalParams := gin.Params{{Key: "id", Value: goal.ID.String()}}
+	w := call(excuseHandler.PostExcuse, "POST", goalParams, `{"date": "`+testToday()+`", "excuseText": "月が綺麗だった"}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var excuse ExcuseResponse
+	json.Unmarshal(w.Body.Bytes(), &excuse)
+	excuseParams := gin.Params{{Key: "id", Value: excuse.ID.String()}}
+	assert.Empty(t, revisions(excuse.ID.String()))
+
+	t.Run("RecordedOnOverwrite", func(t *testing.T) {
+		w := call(excuseHandler.PostExcuse, "POST", goalParams, `{"date": "`+testToday()+`", "excuseText": "雨だった"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		w = call(excuseHandler.PatchExcuse, "PATCH", excuseParams, `{"excuseText": "猫が膝で寝ていた"}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+		// Changing only the mood keeps the wording
+		w = call(excuseHandler.PatchExcuse, "PATCH", excuseParams, `{"mood": 3}`)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		list := revisions(excuse.ID.String())
+		assert.Len(t, list, 2)
+		assert.Equal(t, "雨だった", list[0].ExcuseText)
+		assert.Equal(t, "月が綺麗だった", list[1].ExcuseText)
+	})
+
+	t.Run("Restore", func(t *testing.T) {
+		list := revisions(excuse.ID.String())
+		params := append(excuseParams, gin.Param{Key: "revisionId", Value: list[1].ID.String()})
+		w := call(handler.PostRestoreExcuseRevision, "POST", params, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var restored ExcuseResponse
+		json.Unmarshal(w.Body.Bytes(), &restored)
+		assert.Equal(t, "月が綺麗だった", restored.ExcuseText)
+		assert.Equal(t, 3, *restored.Mood)
+
+		// The replaced wording can be restored in turn
+		list = revisions(excuse.ID.String())
+		assert.Len(t, list, 3)
+		assert.Equal(t, "猫が膝で寝ていた", list[0].ExcuseText)
+	})
+
+	t.Run("OtherExcusesRevision", func(t *testing.T) {
+		other := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: models.Date(time.Now().AddDate(0, 0, -1).Format("2006-01-02")), ExcuseText: "Other"}
+		db.Create(&other)
+		revision := models.ExcuseRevision{UserID: userID, ExcuseID: other.ID, ExcuseText: "Older", WrittenAt: time.Now()}
+		db.Create(&revision)
+
+		params := append(excuseParams, gin.Param{Key: "revisionId", Value: revision.ID.String()})
+		w := call(handler.PostRestoreExcuseRevision, "POST", params, "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		db.Create(&models.ExcuseRevision{UserID: userID, ExcuseID: excuse.ID, ExcuseText: "Long ago", WrittenAt: time.Now().AddDate(0, 0, -41), CreatedAt: time.Now().AddDate(0, 0, -40)})
+		assert.Len(t, revisions(excuse.ID.String()), 4)
+
+		days := 30
+		entitlements.LogRetentionDays = &days
+		defer func() { entitlements.LogRetentionDays = nil }()
+		assert.Len(t, revisions(excuse.ID.String()), 3)
+
+		// Users without a plan are on the free plan
+		assert.NoError(t, services.NewExcuseRevisionService(db).PruneExpired())
+		var count int64
+		db.Model(&models.ExcuseRevision{}).Where("excuse_id = ?", excuse.ID).Count(&count)
+		assert.Equal(t, int64(3), count)
+	})
+}
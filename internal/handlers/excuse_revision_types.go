@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExcuseRevisionResponse struct {
+	ID             uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440004"`
+	ExcuseID       uuid.UUID  `json:"excuseId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	ExcuseText     string     `json:"excuseText" example:"月が綺麗だったので走れませんでした。"`
+	TemplateID     *string    `json:"templateId,omitempty" example:"template_123"`
+	AiGenerationID *uuid.UUID `json:"aiGenerationId,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
+	WrittenAt      time.Time  `json:"writtenAt"`  // When this wording was saved
+	ReplacedAt     time.Time  `json:"replacedAt"` // When it was overwritten
+}
+
+type GetExcuseRevisionsResponse struct {
+	Revisions []ExcuseRevisionResponse `json:"revisions"`
+}
+
+type ExcuseRevisionNotFoundResponse struct {
+	Error string `json:"error" example:"履歴が見つかりません"`
+}
+
+type ExcuseRevisionFetchErrorResponse struct {
+	Error string `json:"error" example:"履歴の取得に失敗しました"`
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+	"what-went-wrong-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxSearchQueryLength = 100
+	maxSearchTerms       = 10
+	defaultSearchLimit   = 20
+	maxSearchLimit       = 100
+)
+
+// likeEscaper escapes the wildcards of a LIKE pattern; backslash is PostgreSQL's default escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchExcuses godoc
+// @Summary Search excuses
+// @Description Find the caller's excuses across all goals whose text contains every space-separated term of q, ignoring case. Works for Japanese without word breaks. Newest first, with the matched ranges of each text for highlighting, in UTF-16 code units. Free plans only search within logRetentionDays.
+// @Tags excuses
+// @Produce json
+// @Param q query string true "Search terms, separated by spaces (max 100 characters)"
+// @Param goalId query string false "Goal ID" format:uuid
+// @Param from query string false "From Date (YYYY-MM-DD)"
+// @Param to query string false "To Date (YYYY-MM-DD)"
+// @Param limit query int false "Max results (1-100, default 20)"
+// @Param offset query int false "Results to skip (default 0)"
+// @Success 200 {object} SearchExcusesResponse
+// @Failure 400 {object} ExcuseValidationErrorResponse
+// @Failure 401 {object} ExcuseUnauthorizedResponse
+// @Failure 500 {object} ExcuseFetchErrorResponse
+// @Security BearerAuth
+// @Router /excuses/search [get]
+func (h *ExcuseHandler) SearchExcuses(c *gin.Context) {
+	userIdStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIdStr.(string)
+
+	q := c.Query("q")
+	terms := strings.Fields(q)
+	if len(terms) == 0 || utf8.RuneCountInString(q) > maxSearchQueryLength || len(terms) > maxSearchTerms {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "検索する言葉を100文字以内で入力してください"})
+		return
+	}
+	limit, offset := defaultSearchLimit, 0
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxSearchLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+			return
+		}
+		limit = n
+	}
+	if v := c.Query("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+			return
+		}
+		offset = n
+	}
+
+	query, ok := filterExcuseDates(c, h.db.Model(&models.ExcuseEntry{}).Where("user_id = ?", userID))
+	if !ok {
+		return
+	}
+	if v := c.Query("goalId"); v != "" {
+		goalID, err := uuid.Parse(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+			return
+		}
+		query = query.Where("goal_id = ?", goalID)
+	}
+	// ILIKE can use the trigram index on excuse_text (see models.CreateExcuseSearchIndex).
+	// Terms of one or two characters have no trigram, so those filters scan the user's excuses instead
+	// and only the user_id index narrows them down.
+	for _, term := range terms {
+		query = query.Where("excuse_text ILIKE ?", "%"+likeEscaper.Replace(term)+"%")
+	}
+
+	// A new session for each statement, since Count would otherwise leave its select on the query
+	query = query.Session(&gorm.Session{})
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の取得に失敗しました"})
+		return
+	}
+	var excuses []models.ExcuseEntry
+	if err := query.Order("date desc, created_at desc").Limit(limit).Offset(offset).Find(&excuses).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "言い訳の取得に失敗しました"})
+		return
+	}
+
+	res := SearchExcusesResponse{Results: make([]SearchExcuseResult, len(excuses)), Total: total}
+	for i, e := range excuses {
+		res.Results[i] = SearchExcuseResult{Excuse: mapToResponse(e), Highlights: highlightRanges(e.ExcuseText, terms)}
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// highlightRanges returns where the terms occur in text, ignoring case, as sorted and merged ranges of
+// UTF-16 code unit offsets, the unit in which JavaScript, Swift's NSString and Kotlin index strings.
+func highlightRanges(text string, terms []string) []TextRange {
+	// utf16Offsets[i] is the UTF-16 offset of the i-th rune of text
+	utf16Offsets := []int{0}
+	for _, r := range text {
+		utf16Offsets = append(utf16Offsets, utf16Offsets[len(utf16Offsets)-1]+utf16.RuneLen(r))
+	}
+
+	haystack := foldRunes(text)
+	covered := make([]bool, len(haystack))
+	for _, term := range terms {
+		needle := foldRunes(term)
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(haystack); i++ {
+			if equalRunes(haystack[i:i+len(needle)], needle) {
+				for j := i; j < i+len(needle); j++ {
+					covered[j] = true
+				}
+			}
+		}
+	}
+
+	ranges := []TextRange{}
+	for i := 0; i < len(covered); i++ {
+		if !covered[i] {
+			continue
+		}
+		start := i
+		for i < len(covered) && covered[i] {
+			i++
+		}
+		ranges = append(ranges, TextRange{Start: utf16Offsets[start], End: utf16Offsets[i]})
+	}
+	return ranges
+}
+
+// foldRunes lowers each rune on its own, so that offsets in the result are offsets in s.
+func foldRunes(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+func equalRunes(a, b []rune) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchExcuses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewExcuseHandler(db, services.NewRuleModerator(nil))
+
+	userID := "auth0|test"
+	run := models.Goal{UserID: userID, Title: "Run"}
+	read := models.Goal{UserID: userID, Title: "Read"}
+	db.Create(&run)
+	db.Create(&read)
+
+	now := time.Now().In(services.LoadLocation(services.DefaultTimeZone))
+	date := func(days int) string { return now.AddDate(0, 0, days).Format("2006-01-02") }
+	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: run.ID, Date: models.Date(date(0)), ExcuseText: "月が綺麗だったので月を見ていた"})
+	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: read.ID, Date: models.Date(date(-1)), ExcuseText: "The Moon was too bright"})
+	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: run.ID, Date: models.Date(date(-40)), ExcuseText: "満月のせい"})
+	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: run.ID, Date: models.Date(date(-2)), ExcuseText: "100%雨"})
+	db.Create(&models.ExcuseEntry{UserID: "auth0|other", GoalID: run.ID, Date: models.Date(date(0)), ExcuseText: "月のせい"})
+
+	search := func(params url.Values, entitlements services.Entitlements) (int, SearchExcusesResponse) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", entitlements)
+		c.Request, _ = http.NewRequest("GET", "/excuses/search?"+params.Encode(), nil)
+		handler.SearchExcuses(c)
+		var res SearchExcusesResponse
+		json.Unmarshal(w.Body.Bytes(), &res)
+		return w.Code, res
+	}
+
+	t.Run("Japanese", func(t *testing.T) {
+		code, res := search(url.Values{"q": {"月"}}, services.Entitlements{})
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, int64(2), res.Total)
+		assert.Equal(t, "月が綺麗だったので月を見ていた", res.Results[0].Excuse.ExcuseText)
+		assert.Equal(t, []TextRange{{Start: 0, End: 1}, {Start: 9, End: 10}}, res.Results[0].Highlights)
+		assert.Equal(t, "満月のせい", res.Results[1].Excuse.ExcuseText)
+	})
+
+	t.Run("EveryTermIgnoringCase", func(t *testing.T) {
+		_, res := search(url.Values{"q": {"moon BRIGHT"}}, services.Entitlements{})
+		assert.Len(t, res.Results, 1)
+		assert.Equal(t, []TextRange{{Start: 4, End: 8}, {Start: 17, End: 23}}, res.Results[0].Highlights)
+
+		_, res = search(url.Values{"q": {"moon 月"}}, services.Entitlements{})
+		assert.Empty(t, res.Results)
+	})
+
+	t.Run("WildcardsAreLiteral", func(t *testing.T) {
+		_, res := search(url.Values{"q": {"%"}}, services.Entitlements{})
+		assert.Len(t, res.Results, 1)
+		assert.Equal(t, "100%雨", res.Results[0].Excuse.ExcuseText)
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		_, res := search(url.Values{"q": {"月"}, "goalId": {read.ID.String()}}, services.Entitlements{})
+		assert.Empty(t, res.Results)
+		_, res = search(url.Values{"q": {"月"}, "from": {date(-7)}}, services.Entitlements{})
+		assert.Len(t, res.Results, 1)
+	})
+
+	t.Run("Retention", func(t *testing.T) {
+		days := 30
+		_, res := search(url.Values{"q": {"月"}}, services.Entitlements{LogRetentionDays: &days})
+		assert.Equal(t, int64(1), res.Total)
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		_, res := search(url.Values{"q": {"月"}, "limit": {"1"}, "offset": {"1"}}, services.Entitlements{})
+		assert.Equal(t, int64(2), res.Total)
+		assert.Len(t, res.Results, 1)
+		assert.Equal(t, "満月のせい", res.Results[0].Excuse.ExcuseText)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		code, _ := search(url.Values{"q": {"  "}}, services.Entitlements{})
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, _ = search(url.Values{"q": {"月"}, "limit": {"0"}}, services.Entitlements{})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+}
+
+func TestHighlightRanges(t *testing.T) {
+	t.Run("Japanese", func(t *testing.T) {
+		assert.Equal(t, []TextRange{{Start: 0, End: 1}, {Start: 9, End: 10}}, highlightRanges("月が綺麗だったので月を見ていた", []string{"月"}))
+	})
+
+	t.Run("OverlappingTermsMerge", func(t *testing.T) {
+		assert.Equal(t, []TextRange{{Start: 0, End: 5}}, highlightRanges("Moonlight", []string{"moon", "ONL"}))
+	})
+
+	t.Run("UTF16Offsets", func(t *testing.T) {
+		// The emoji takes two UTF-16 code units
+		assert.Equal(t, []TextRange{{Start: 2, End: 3}}, highlightRanges("🏃走れなかった", []string{"走"}))
+	})
+
+	t.Run("NoMatch", func(t *testing.T) {
+		assert.Equal(t, []TextRange{}, highlightRanges("雨", []string{"晴"}))
+	})
+}
@@ -20,11 +20,12 @@ func NewExcuseTemplateHandler(db *gorm.DB) *ExcuseTemplateHandler {
 
 // GetTemplates godoc
 // @Summary List excuse templates
-// @Description Get excuse templates. Can filter by pack_id. Premium users can access all. Free users restricted from premium packs.
+// @Description Get excuse templates. Can filter by pack_id and tone. Premium users can access all. Free users restricted from premium packs.
 // @Tags excuse-templates
 // @Accept json
 // @Produce json
 // @Param pack_id query string false "Pack ID to filter"
+// @Param tone query string false "Tone ID to filter"
 // @Success 200 {object} GetExcuseTemplatesResponse
 // @Security BearerAuth
 // @Router /excuse-templates [get]
@@ -47,6 +48,10 @@ func (h *ExcuseTemplateHandler) GetExcuseTemplates(c *gin.Context) {
 		query = query.Where("pack_id = ?", packID)
 	}
 
+	if tone := c.Query("tone"); tone != "" {
+		query = query.Where("? = ANY(tones)", tone)
+	}
+
 	// Filter premium if not entitled
 	// Actually spec says "free users restricted from premium packs".
 	// Implementation: list all but maybe show isPremium? Or hide premium templates?
@@ -69,6 +74,7 @@ func (h *ExcuseTemplateHandler) GetExcuseTemplates(c *gin.Context) {
 			PackID:     t.PackID,
 			ExcuseText: t.Text,
 			Tags:       t.Tags,
+			Tones:      t.Tones,
 			IsPremium:  t.IsPremium,
 			CreatedAt:  t.CreatedAt,
 		}
@@ -119,6 +125,7 @@ func (h *ExcuseTemplateHandler) GetExcuseTemplate(c *gin.Context) {
 		PackID:     t.PackID,
 		ExcuseText: t.Text,
 		Tags:       t.Tags,
+		Tones:      t.Tones,
 		IsPremium:  t.IsPremium,
 		CreatedAt:  t.CreatedAt,
 	}
@@ -121,4 +121,33 @@ func TestGetExcuseTemplates(t *testing.T) {
 		assert.Len(t, resp.Templates, 1)
 		assert.Equal(t, "pack-1", resp.Templates[0].PackID)
 	})
+
+	t.Run("FilterByTone", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		db.AutoMigrate(&models.ExcuseTemplate{})
+		handler := NewExcuseTemplateHandler(db)
+
+		t1 := models.ExcuseTemplate{ID: "t1", Tones: pq.StringArray{"casual", "serious"}}
+		t2 := models.ExcuseTemplate{ID: "t2", Tones: pq.StringArray{"surreal"}}
+		db.Create(&t1)
+		db.Create(&t2)
+
+		userID := uuid.New()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID.String())
+		c.Set("entitlements", services.Entitlements{CanUsePremiumTemplates: true})
+		c.Request, _ = http.NewRequest("GET", "/excuse-templates?tone=serious", nil)
+
+		handler.GetExcuseTemplates(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp GetExcuseTemplatesResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+
+		assert.Len(t, resp.Templates, 1)
+		assert.Equal(t, "t1", resp.Templates[0].ID)
+		assert.Equal(t, []string{"casual", "serious"}, resp.Templates[0].Tones)
+	})
 }
@@ -7,6 +7,7 @@ type ExcuseTemplateResponse struct {
 	PackID     string    `json:"packId" example:"pack_abc"`
 	ExcuseText string    `json:"excuseText" example:"宿題を犬に食べられました。"`
 	Tags       []string  `json:"tags" example:"面白い,定番"`
+	Tones      []string  `json:"tones" example:"casual,serious"`
 	IsPremium  bool      `json:"isPremium" example:"false"`
 	CreatedAt  time.Time `json:"createdAt"`
 }
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 	"what-went-wrong-api/internal/models"
@@ -19,18 +21,18 @@ func TestGetExcuses_Retention(t *testing.T) {
 	db, cleanup := SetupTestDB(t)
 	defer cleanup()
 
-	handler := NewExcuseHandler(db)
+	handler := NewExcuseHandler(db, services.NewRuleModerator(nil))
 
 	userID := "auth0|test"
 	goalID := uuid.New()
 
 	// Seed Old Excuse (40 days ago)
 	oldDate := time.Now().AddDate(0, 0, -40).Format("2006-01-02")
-	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: goalID, Date: oldDate, ExcuseText: "Old"})
+	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: goalID, Date: models.Date(oldDate), ExcuseText: "Old"})
 
 	// Seed New Excuse (Today)
 	newDate := time.Now().Format("2006-01-02")
-	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: goalID, Date: newDate, ExcuseText: "New"})
+	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: goalID, Date: models.Date(newDate), ExcuseText: "New"})
 
 	t.Run("FreeUser_Restricted", func(t *testing.T) {
 		days := 30
@@ -69,14 +71,53 @@ func TestGetExcuses_Retention(t *testing.T) {
 	})
 }
 
-func TestPostExcuse_Upsert(t *testing.T) {
+func TestGetExcuseToday_UserTimeZone(t *testing.T) {
 	db, cleanup := SetupTestDB(t)
 	defer cleanup()
 
-	handler := NewExcuseHandler(db)
+	handler := NewExcuseHandler(db, services.NewRuleModerator(nil))
 	userID := "auth0|test"
 	goalID := uuid.New()
-	today := time.Now().Format("2006-01-02")
+
+	// UTC+14 is always on a different date than UTC-10
+	ahead, _ := time.LoadLocation("Pacific/Kiritimati")
+	behind, _ := time.LoadLocation("Pacific/Honolulu")
+	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: goalID, Date: models.Date(time.Now().In(ahead).Format("2006-01-02")), ExcuseText: "Ahead"})
+
+	tests := []struct {
+		name           string
+		location       *time.Location
+		expectedStatus int
+	}{
+		{name: "SameDate", location: ahead, expectedStatus: http.StatusOK},
+		{name: "OtherDate", location: behind, expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("userID", userID)
+			c.Set("location", tt.location)
+			c.Params = gin.Params{{Key: "id", Value: goalID.String()}}
+			c.Request, _ = http.NewRequest("GET", "/goals/"+goalID.String()+"/excuses/today", nil)
+
+			handler.GetExcuseToday(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestPostExcuse_Upsert(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	handler := NewExcuseHandler(db, services.NewRuleModerator(nil))
+	userID := "auth0|test"
+	goal := models.Goal{UserID: userID, Title: "Goal"}
+	db.Create(&goal)
+	goalID := goal.ID
+	today := testToday()
 
 	// 1. Create New
 	w := httptest.NewRecorder()
@@ -115,19 +156,164 @@ func TestPostExcuse_Upsert(t *testing.T) {
 	assert.Equal(t, "Updated Excuse", entry.ExcuseText)
 }
 
+func TestPostExcuse_MultiplePerDay(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	handler := NewExcuseHandler(db, services.NewRuleModerator(nil))
+	userID := "auth0|test"
+	goal := models.Goal{UserID: userID, Title: "Drink water 3x a day", MaxExcusesPerDay: 3}
+	db.Create(&goal)
+	today := testToday()
+
+	post := func(text string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", services.Entitlements{})
+		c.Params = gin.Params{{Key: "id", Value: goal.ID.String()}}
+		c.Request, _ = http.NewRequest("POST", "/goals/"+goal.ID.String()+"/excuses", strings.NewReader(`{"date": "`+today+`", "excuseText": "`+text+`"}`))
+		handler.PostExcuse(c)
+		return w
+	}
+
+	assert.Equal(t, http.StatusCreated, post("Morning").Code)
+	assert.Equal(t, http.StatusCreated, post("Noon").Code)
+	w := post("Evening")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, http.StatusConflict, post("Night").Code)
+
+	// A trashed excuse frees its slot, and a new one takes it
+	var evening ExcuseResponse
+	json.Unmarshal(w.Body.Bytes(), &evening)
+	db.Delete(&models.ExcuseEntry{}, "id = ?", evening.ID)
+	assert.Equal(t, http.StatusCreated, post("Night").Code)
+
+	var texts []string
+	db.Model(&models.ExcuseEntry{}).Where("goal_id = ?", goal.ID).Order("slot").Pluck("excuse_text", &texts)
+	assert.Equal(t, []string{"Morning", "Noon", "Night"}, texts)
+}
+
+func TestPostExcuse_ConcurrentAtCap(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	handler := NewExcuseHandler(db, services.NewRuleModerator(nil))
+	userID := "auth0|test"
+	goal := models.Goal{UserID: userID, Title: "Drink water 3x a day", MaxExcusesPerDay: 3}
+	db.Create(&goal)
+	today := testToday()
+
+	// Saves racing for the same day get a slot each or 409, never a duplicate slot
+	statuses := make([]int, 6)
+	var wg sync.WaitGroup
+	for i := range statuses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("userID", userID)
+			c.Set("entitlements", services.Entitlements{})
+			c.Params = gin.Params{{Key: "id", Value: goal.ID.String()}}
+			c.Request, _ = http.NewRequest("POST", "/goals/"+goal.ID.String()+"/excuses", strings.NewReader(`{"date": "`+today+`", "excuseText": "Excuse"}`))
+			handler.PostExcuse(c)
+			statuses[i] = w.Code
+		}(i)
+	}
+	wg.Wait()
+
+	created, conflicts := 0, 0
+	for _, status := range statuses {
+		switch status {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+			conflicts++
+		}
+	}
+	assert.Equal(t, 3, created)
+	assert.Equal(t, 3, conflicts)
+}
+
+func TestPostExcuse_DatePolicy(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	handler := NewExcuseHandler(db, services.NewRuleModerator(nil))
+	userID := "auth0|test"
+	loc := services.LoadLocation(services.DefaultTimeZone)
+	now := time.Now().In(loc)
+	days := 30
+
+	newGoal := models.Goal{UserID: userID, Title: "New Goal"}
+	db.Create(&newGoal)
+	oldGoal := models.Goal{UserID: userID, Title: "Old Goal", CreatedAt: now.AddDate(0, 0, -60)}
+	db.Create(&oldGoal)
+	archivedGoal := models.Goal{UserID: userID, Title: "Archived Goal", CreatedAt: now.AddDate(0, 0, -60), ArchivedAt: &now}
+	db.Create(&archivedGoal)
+	startDate, endDate := now.AddDate(0, 0, -20).Format("2006-01-02"), now.AddDate(0, 0, -10).Format("2006-01-02")
+	challengeGoal := models.Goal{UserID: userID, Title: "Challenge Goal", CreatedAt: now.AddDate(0, 0, -60), StartDate: models.DatePtr(&startDate), EndDate: models.DatePtr(&endDate)}
+	db.Create(&challengeGoal)
+	pausedGoal := models.Goal{UserID: userID, Title: "Paused Goal", CreatedAt: now.AddDate(0, 0, -60)}
+	db.Create(&pausedGoal)
+	db.Create(&models.PausePeriod{UserID: userID, GoalID: &pausedGoal.ID, StartDate: models.Date(now.AddDate(0, 0, -5).Format("2006-01-02")), EndDate: models.Date(now.AddDate(0, 0, -3).Format("2006-01-02"))})
+	// A vacation of another user does not pause this one's goals
+	db.Create(&models.PausePeriod{UserID: "auth0|other", StartDate: models.Date(now.AddDate(0, 0, -60).Format("2006-01-02")), EndDate: models.Date(now.Format("2006-01-02"))})
+
+	tests := []struct {
+		name           string
+		goal           models.Goal
+		date           string
+		entitlements   services.Entitlements
+		expectedStatus int
+	}{
+		{name: "InvalidFormat", goal: newGoal, date: "2023-13-45", expectedStatus: http.StatusBadRequest},
+		{name: "NonexistentDay", goal: newGoal, date: "2023-02-30", expectedStatus: http.StatusBadRequest},
+		{name: "Future", goal: newGoal, date: now.AddDate(0, 0, 1).Format("2006-01-02"), expectedStatus: http.StatusBadRequest},
+		{name: "BeforeGoalCreated", goal: newGoal, date: now.AddDate(0, 0, -1).Format("2006-01-02"), expectedStatus: http.StatusBadRequest},
+		{name: "OutsideRetention", goal: oldGoal, date: now.AddDate(0, 0, -40).Format("2006-01-02"), entitlements: services.Entitlements{LogRetentionDays: &days}, expectedStatus: http.StatusForbidden},
+		{name: "Backfill", goal: oldGoal, date: now.AddDate(0, 0, -40).Format("2006-01-02"), expectedStatus: http.StatusCreated},
+		{name: "ArchivedGoal", goal: archivedGoal, date: now.Format("2006-01-02"), expectedStatus: http.StatusConflict},
+		{name: "BeforeStartDate", goal: challengeGoal, date: now.AddDate(0, 0, -21).Format("2006-01-02"), expectedStatus: http.StatusBadRequest},
+		{name: "AfterEndDate", goal: challengeGoal, date: now.AddDate(0, 0, -9).Format("2006-01-02"), expectedStatus: http.StatusBadRequest},
+		{name: "StartDay", goal: challengeGoal, date: startDate, expectedStatus: http.StatusCreated},
+		{name: "EndDay", goal: challengeGoal, date: endDate, expectedStatus: http.StatusCreated},
+		{name: "PausedDay", goal: pausedGoal, date: now.AddDate(0, 0, -4).Format("2006-01-02"), expectedStatus: http.StatusConflict},
+		{name: "AfterPause", goal: pausedGoal, date: now.AddDate(0, 0, -2).Format("2006-01-02"), expectedStatus: http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("userID", userID)
+			c.Set("entitlements", tt.entitlements)
+			c.Params = gin.Params{{Key: "id", Value: tt.goal.ID.String()}}
+			reqBody := `{"date": "` + tt.date + `", "excuseText": "Excuse"}`
+			c.Request, _ = http.NewRequest("POST", "/goals/"+tt.goal.ID.String()+"/excuses", strings.NewReader(reqBody))
+
+			handler.PostExcuse(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
 func TestPostExcuse_PremiumTemplate(t *testing.T) {
 	db, cleanup := SetupTestDB(t)
 	defer cleanup()
 
-	handler := NewExcuseHandler(db)
+	handler := NewExcuseHandler(db, services.NewRuleModerator(nil))
 	userID := "auth0|test"
-	goalID := uuid.New()
+	goal := models.Goal{UserID: userID, Title: "Goal"}
+	db.Create(&goal)
+	goalID := goal.ID
 
 	// Create Premium Template
 	db.AutoMigrate(&models.ExcuseTemplate{})
 	db.Create(&models.ExcuseTemplate{ID: "tmpl-premium", Text: "Premium", IsPremium: true})
 
-	today := time.Now().Format("2006-01-02")
+	today := testToday()
 
 	// Free user try to use premium template
 	w := httptest.NewRecorder()
@@ -142,3 +328,167 @@ func TestPostExcuse_PremiumTemplate(t *testing.T) {
 	handler.PostExcuse(c)
 	assert.Equal(t, http.StatusForbidden, w.Code)
 }
+
+func TestPostExcuse_Moderation(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	moderator := services.NewRuleModerator([]services.ModerationRule{
+		{Pattern: regexp.MustCompile(`NG`), Action: services.ModerationActionReject},
+		{Pattern: regexp.MustCompile(`secret`), Action: services.ModerationActionMask},
+		{Pattern: regexp.MustCompile(`suspicious`), Action: services.ModerationActionFlag},
+	})
+	handler := NewExcuseHandler(db, moderator)
+	userID := "auth0|test"
+	goal := models.Goal{UserID: userID, Title: "Goal"}
+	db.Create(&goal)
+	goalID := goal.ID
+	today := testToday()
+
+	post := func(text string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", services.Entitlements{})
+		c.Params = gin.Params{{Key: "id", Value: goalID.String()}}
+		reqBody := `{"date": "` + today + `", "excuseText": "` + text + `"}`
+		c.Request, _ = http.NewRequest("POST", "/goals/"+goalID.String()+"/excuses", strings.NewReader(reqBody))
+		handler.PostExcuse(c)
+		return w
+	}
+
+	t.Run("Reject", func(t *testing.T) {
+		w := post("NG word")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Mask", func(t *testing.T) {
+		w := post("my secret")
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var resp ExcuseResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, "my ＊＊＊＊＊＊", resp.ExcuseText)
+	})
+
+	t.Run("Flag", func(t *testing.T) {
+		w := post("suspicious excuse")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var review models.ModerationReview
+		err := db.Where("user_id = ?", userID).First(&review).Error
+		assert.NoError(t, err)
+		assert.Equal(t, "pending", review.Status)
+		assert.Equal(t, "suspicious excuse", review.Text)
+	})
+}
+
+func TestExcuseMoodAndTags(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	handler := NewExcuseHandler(db, services.NewRuleModerator(nil))
+	userID := "auth0|test"
+	loc := services.LoadLocation(services.DefaultTimeZone)
+	now := time.Now().In(loc)
+	goal := models.Goal{UserID: userID, Title: "Goal", CreatedAt: now.AddDate(0, 0, -10)}
+	db.Create(&goal)
+
+	post := func(days int, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", services.Entitlements{})
+		c.Params = gin.Params{{Key: "id", Value: goal.ID.String()}}
+		reqBody := `{"date": "` + now.AddDate(0, 0, -days).Format("2006-01-02") + `", "excuseText": "Excuse"` + body + `}`
+		c.Request, _ = http.NewRequest("POST", "/goals/"+goal.ID.String()+"/excuses", strings.NewReader(reqBody))
+		handler.PostExcuse(c)
+		return w
+	}
+	get := func(handle gin.HandlerFunc, query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", services.Entitlements{})
+		c.Params = gin.Params{{Key: "id", Value: goal.ID.String()}}
+		c.Request, _ = http.NewRequest("GET", "/goals/"+goal.ID.String()+query, nil)
+		handle(c)
+		return w
+	}
+
+	assert.Equal(t, http.StatusBadRequest, post(1, `, "mood": 6`).Code)
+
+	w := post(1, `, "mood": 4, "tags": [" 仕事 ", "体調", "仕事"], "note": "残業"`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var excuse ExcuseResponse
+	json.Unmarshal(w.Body.Bytes(), &excuse)
+	assert.Equal(t, []string{"仕事", "体調"}, excuse.Tags)
+	assert.Equal(t, "残業", *excuse.Note)
+
+	post(2, `, "mood": 2, "tags": ["仕事"]`)
+	post(3, `, "tags": ["天気"]`)
+
+	// Overwriting the text keeps the mood and tags
+	w = post(1, ``)
+	json.Unmarshal(w.Body.Bytes(), &excuse)
+	assert.Equal(t, 4, *excuse.Mood)
+
+	t.Run("Filters", func(t *testing.T) {
+		var resp GetExcusesResponse
+		json.Unmarshal(get(handler.GetExcuses, "/excuses?tag=仕事").Body.Bytes(), &resp)
+		assert.Len(t, resp.Excuses, 2)
+		json.Unmarshal(get(handler.GetExcuses, "/excuses?mood=2").Body.Bytes(), &resp)
+		assert.Len(t, resp.Excuses, 1)
+		assert.Equal(t, http.StatusBadRequest, get(handler.GetExcuses, "/excuses?mood=9").Code)
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		w := get(handler.GetGoalStats, "/stats")
+		assert.Equal(t, http.StatusOK, w.Code)
+		var stats GoalStatsResponse
+		json.Unmarshal(w.Body.Bytes(), &stats)
+		assert.Equal(t, 3, stats.ExcuseCount)
+		assert.InDelta(t, 3.0, *stats.AverageMood, 1e-9)
+		assert.Equal(t, []MoodCount{{Mood: 2, Count: 1}, {Mood: 4, Count: 1}}, stats.MoodCounts)
+		assert.Equal(t, "仕事", *stats.MostCommonTag)
+		assert.Equal(t, TagCount{Tag: "仕事", Count: 2}, stats.TagCounts[0])
+		assert.Len(t, stats.TagCounts, 3)
+	})
+}
+
+func TestPatchExcuse_AiGeneration(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	handler := NewExcuseHandler(db, services.NewRuleModerator(nil))
+	userID := "auth0|test"
+	goal := models.Goal{UserID: userID, Title: "Goal"}
+	db.Create(&goal)
+	generation := models.AiGeneration{UserID: userID, GoalID: goal.ID, Date: models.Date(testToday()), Candidates: []string{"AI excuse"}, Model: "mock"}
+	db.Create(&generation)
+	excuse := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: models.Date(testToday()), ExcuseText: "AI excuse", AiGenerationID: &generation.ID}
+	db.Create(&excuse)
+
+	patch := func(body string) ExcuseResponse {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", services.Entitlements{})
+		c.Params = gin.Params{{Key: "id", Value: excuse.ID.String()}}
+		c.Request, _ = http.NewRequest("PATCH", "/excuses/"+excuse.ID.String(), strings.NewReader(body))
+		handler.PatchExcuse(c)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp ExcuseResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+
+	// Resending the same text or changing only the mood keeps the link to the generation
+	resp := patch(`{"excuseText": "AI excuse", "mood": 3}`)
+	assert.NotNil(t, resp.AiGenerationID)
+
+	resp = patch(`{"excuseText": "Rewritten"}`)
+	assert.Nil(t, resp.AiGenerationID)
+	var saved models.ExcuseEntry
+	db.First(&saved, "id = ?", excuse.ID)
+	assert.Nil(t, saved.AiGenerationID)
+}
@@ -7,30 +7,59 @@ import (
 )
 
 type CreateExcuseRequest struct {
-	Date       string `json:"date" binding:"required" example:"2023-10-27"` // YYYY-MM-DD
-	ExcuseText string `json:"excuseText" binding:"required,max=500" example:"寝坊しました。"`
-	TemplateID string `json:"templateId" example:"template_123"`
+	Date       Date     `json:"date" binding:"required" swaggertype:"string" format:"date" example:"2023-10-27"`
+	ExcuseText string   `json:"excuseText" binding:"required,max=500" example:"寝坊しました。"`
+	TemplateID string   `json:"templateId" example:"template_123"`
+	Mood       *int     `json:"mood" binding:"omitempty,min=1,max=5" example:"3"` // 1 (fine) to 5 (terrible)
+	Tags       []string `json:"tags" binding:"omitempty,max=10,dive,max=30" example:"仕事,体調"`
+	Note       *string  `json:"note" binding:"omitempty,max=1000" example:"次は前日に準備する"`
 }
 
 type UpdateExcuseRequest struct {
-	ExcuseText string `json:"excuseText" binding:"max=500" example:"盛大に寝坊しました。"`
-	TemplateID string `json:"templateId" example:"template_123"`
+	ExcuseText string    `json:"excuseText" binding:"max=500" example:"盛大に寝坊しました。"`
+	TemplateID string    `json:"templateId" example:"template_123"`
+	Mood       *int      `json:"mood" binding:"omitempty,min=0,max=5" example:"4"`         // 0 clears it
+	Tags       *[]string `json:"tags" binding:"omitempty,max=10,dive,max=30" example:"天気"` // [] clears them
+	Note       *string   `json:"note" binding:"omitempty,max=1000" example:"雨だった"`         // "" clears it
 }
 
 type ExcuseResponse struct {
-	ID         uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	GoalID     uuid.UUID `json:"goalId" example:"550e8400-e29b-41d4-a716-446655440001"`
-	Date       string    `json:"date" example:"2023-10-27"`
-	ExcuseText string    `json:"excuseText" example:"寝坊しました。"`
-	TemplateID *string   `json:"templateId,omitempty" example:"template_123"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
+	ID             uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	GoalID         uuid.UUID  `json:"goalId" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Date           string     `json:"date" example:"2023-10-27"`
+	ExcuseText     string     `json:"excuseText" example:"寝坊しました。"`
+	TemplateID     *string    `json:"templateId,omitempty" example:"template_123"`
+	Mood           *int       `json:"mood,omitempty" example:"3"`
+	Tags           []string   `json:"tags" example:"仕事,体調"`
+	Note           *string    `json:"note,omitempty" example:"次は前日に準備する"`
+	AiGenerationID *uuid.UUID `json:"aiGenerationId,omitempty" example:"550e8400-e29b-41d4-a716-446655440002"`
+	Version        int        `json:"version" example:"1"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
 }
 
 type GetExcusesResponse struct {
 	Excuses []ExcuseResponse `json:"excuses"`
 }
 
+type MoodCount struct {
+	Mood  int `json:"mood" example:"3"`
+	Count int `json:"count" example:"4"`
+}
+
+type TagCount struct {
+	Tag   string `json:"tag" example:"仕事"`
+	Count int    `json:"count" example:"7"`
+}
+
+type GoalStatsResponse struct {
+	ExcuseCount   int         `json:"excuseCount" example:"12"`
+	AverageMood   *float64    `json:"averageMood,omitempty" example:"3.2"`  // Over the excuses with a mood
+	MoodCounts    []MoodCount `json:"moodCounts"`                           // Moods that occur, 1 first
+	TagCounts     []TagCount  `json:"tagCounts"`                            // Most frequent first
+	MostCommonTag *string     `json:"mostCommonTag,omitempty" example:"仕事"` // The most common reason, absent when no excuse has tags
+}
+
 type ExcuseValidationErrorResponse struct {
 	Error string `json:"error" example:"入力内容が正しくありません"`
 }
@@ -59,6 +88,26 @@ type ExcuseForbiddenResponse struct {
 	Error string `json:"error" example:"プレミアムテンプレートを利用するにはプレミアムプランが必要です"`
 }
 
+type ExcuseGoalArchivedResponse struct {
+	Error string `json:"error" example:"アーカイブした目標には言い訳を保存できません"`
+}
+
 type ExcuseNotFoundResponse struct {
 	Error string `json:"error" example:"言い訳が見つかりません"`
 }
+
+// TextRange is a span of text, counted in UTF-16 code units, from Start up to but not including End.
+type TextRange struct {
+	Start int `json:"start" example:"2"`
+	End   int `json:"end" example:"4"`
+}
+
+type SearchExcuseResult struct {
+	Excuse     ExcuseResponse `json:"excuse"`
+	Highlights []TextRange    `json:"highlights"` // Where the search terms occur in excuse.excuseText
+}
+
+type SearchExcusesResponse struct {
+	Results []SearchExcuseResult `json:"results"`
+	Total   int64                `json:"total" example:"42"` // Matches before limit and offset
+}
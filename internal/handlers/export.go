@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"what-went-wrong-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ExportHandler struct {
+	db *gorm.DB
+}
+
+func NewExportHandler(db *gorm.DB) *ExportHandler {
+	return &ExportHandler{db: db}
+}
+
+// PostMeExports godoc
+// @Summary Request data export
+// @Description Start building a ZIP of the user's profile, plan, goals, excuses (JSON and CSV) and AI history. The archive is built in the background; poll GET /me/exports/{id} until it is completed. An export already in progress is returned instead of starting another one.
+// @Tags exports
+// @Produce json
+// @Success 202 {object} ExportResponse
+// @Failure 401 {object} ExportUnauthorizedResponse
+// @Failure 500 {object} ExportCreateErrorResponse
+// @Security BearerAuth
+// @Router /me/exports [post]
+func (h *ExportHandler) PostMeExports(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var export models.DataExport
+	err := h.db.Omit("archive").Where("user_id = ? AND status IN ?", userID, []string{"pending", "processing"}).First(&export).Error
+	if err == nil {
+		c.JSON(http.StatusAccepted, mapToExportResponse(export))
+		return
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エクスポートの作成に失敗しました"})
+		return
+	}
+
+	// A concurrent request may have started one since; the index on exports in progress
+	// lets only one insert through, and the others return it
+	export = models.DataExport{UserID: userID, Status: "pending"}
+	result := h.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&export)
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エクスポートの作成に失敗しました"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		export = models.DataExport{}
+		if err := h.db.Omit("archive").Where("user_id = ? AND status IN ?", userID, []string{"pending", "processing"}).First(&export).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "エクスポートの作成に失敗しました"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusAccepted, mapToExportResponse(export))
+}
+
+// GetMeExport godoc
+// @Summary Get data export status
+// @Tags exports
+// @Produce json
+// @Param id path string true "Export ID" format:uuid
+// @Success 200 {object} ExportResponse
+// @Failure 400 {object} ExportValidationErrorResponse
+// @Failure 401 {object} ExportUnauthorizedResponse
+// @Failure 404 {object} ExportNotFoundResponse
+// @Failure 500 {object} ExportFetchErrorResponse
+// @Security BearerAuth
+// @Router /me/exports/{id} [get]
+func (h *ExportHandler) GetMeExport(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var export models.DataExport
+	if err := h.db.Omit("archive").First(&export, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "エクスポートが見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エクスポートの取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapToExportResponse(export))
+}
+
+// GetMeExportDownload godoc
+// @Summary Download data export
+// @Tags exports
+// @Produce application/zip
+// @Param id path string true "Export ID" format:uuid
+// @Success 200 {file} file "ZIP archive"
+// @Failure 400 {object} ExportValidationErrorResponse
+// @Failure 401 {object} ExportUnauthorizedResponse
+// @Failure 404 {object} ExportNotFoundResponse
+// @Failure 409 {object} ExportNotReadyResponse
+// @Failure 500 {object} ExportFetchErrorResponse
+// @Security BearerAuth
+// @Router /me/exports/{id}/download [get]
+func (h *ExportHandler) GetMeExportDownload(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var export models.DataExport
+	if err := h.db.First(&export, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "エクスポートが見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "エクスポートの取得に失敗しました"})
+		return
+	}
+	if export.Status != "completed" {
+		c.JSON(http.StatusConflict, gin.H{"error": "エクスポートはまだ完了していません"})
+		return
+	}
+
+	filename := fmt.Sprintf("what-went-wrong-export-%s.zip", export.CompletedAt.Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "application/zip", export.Archive)
+}
+
+func mapToExportResponse(e models.DataExport) ExportResponse {
+	res := ExportResponse{
+		ID:          e.ID,
+		Status:      e.Status,
+		CreatedAt:   e.CreatedAt,
+		CompletedAt: e.CompletedAt,
+		ExpiresAt:   e.ExpiresAt,
+	}
+	if e.Status == "completed" {
+		res.Size = e.Size
+		res.DownloadURL = fmt.Sprintf("/api/v1/me/exports/%s/download", e.ID)
+	}
+	return res
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostMeExports(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	gin.SetMode(gin.TestMode)
+	handler := NewExportHandler(db)
+	userID := "auth0|test"
+
+	post := func() ExportResponse {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Request, _ = http.NewRequest("POST", "/me/exports", nil)
+		handler.PostMeExports(c)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var resp ExportResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+
+	first := post()
+	assert.Equal(t, "pending", first.Status)
+
+	// An export in progress is reused
+	second := post()
+	assert.Equal(t, first.ID, second.ID)
+
+	// Concurrent requests start a single export
+	db.Model(&models.DataExport{}).Where("id = ?", first.ID).Update("status", "failed")
+	responses := make([]ExportResponse, 5)
+	var wg sync.WaitGroup
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			responses[i] = post()
+		}(i)
+	}
+	wg.Wait()
+	for _, resp := range responses {
+		assert.Equal(t, responses[0].ID, resp.ID)
+	}
+	assert.NotEqual(t, first.ID, responses[0].ID)
+	var inProgress int64
+	db.Model(&models.DataExport{}).Where("user_id = ? AND status IN ?", userID, []string{"pending", "processing"}).Count(&inProgress)
+	assert.Equal(t, int64(1), inProgress)
+}
+
+func TestRemoveExpiredExports(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	now := time.Now()
+	expiresAt := now.Add(-time.Hour)
+	expired := models.DataExport{UserID: "auth0|test", Status: "completed", ExpiresAt: &expiresAt}
+	db.Create(&expired)
+	oldFailure := models.DataExport{UserID: "auth0|test", Status: "failed"}
+	db.Create(&oldFailure)
+	db.Model(&oldFailure).UpdateColumn("updated_at", now.AddDate(0, 0, -8))
+	recentFailure := models.DataExport{UserID: "auth0|test", Status: "failed"}
+	db.Create(&recentFailure)
+
+	assert.NoError(t, services.NewExportService(db).RemoveExpired())
+
+	var ids []uuid.UUID
+	db.Model(&models.DataExport{}).Pluck("id", &ids)
+	assert.Equal(t, []uuid.UUID{recentFailure.ID}, ids)
+}
+
+func TestGetMeExportDownload(t *testing.T) {
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+
+	gin.SetMode(gin.TestMode)
+	handler := NewExportHandler(db)
+	userID := "auth0|test"
+
+	now := time.Now()
+	completed := models.DataExport{UserID: userID, Status: "completed", Archive: []byte("PK"), Size: 2, CompletedAt: &now}
+	db.Create(&completed)
+	pending := models.DataExport{UserID: userID, Status: "pending"}
+	db.Create(&pending)
+
+	tests := []struct {
+		name           string
+		export         models.DataExport
+		userID         string
+		expectedStatus int
+	}{
+		{name: "Completed", export: completed, userID: userID, expectedStatus: http.StatusOK},
+		{name: "NotReady", export: pending, userID: userID, expectedStatus: http.StatusConflict},
+		{name: "OtherUser", export: completed, userID: "auth0|other", expectedStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("userID", tt.userID)
+			c.Params = gin.Params{{Key: "id", Value: tt.export.ID.String()}}
+			c.Request, _ = http.NewRequest("GET", "/me/exports/"+tt.export.ID.String()+"/download", nil)
+
+			handler.GetMeExportDownload(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+				assert.Equal(t, "PK", w.Body.String())
+			}
+		})
+	}
+}
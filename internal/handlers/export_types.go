@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ExportResponse struct {
+	ID          uuid.UUID  `json:"id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	Status      string     `json:"status" example:"completed"` // "pending", "processing", "completed", "failed"
+	Size        int        `json:"size,omitempty" example:"20480"`
+	DownloadURL string     `json:"downloadUrl,omitempty" example:"/api/v1/me/exports/550e8400-e29b-41d4-a716-446655440003/download"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+type ExportValidationErrorResponse struct {
+	Error string `json:"error" example:"入力内容が正しくありません"`
+}
+
+type ExportUnauthorizedResponse struct {
+	Error string `json:"error" example:"認証されていません"`
+}
+
+type ExportNotFoundResponse struct {
+	Error string `json:"error" example:"エクスポートが見つかりません"`
+}
+
+type ExportNotReadyResponse struct {
+	Error string `json:"error" example:"エクスポートはまだ完了していません"`
+}
+
+type ExportFetchErrorResponse struct {
+	Error string `json:"error" example:"エクスポートの取得に失敗しました"`
+}
+
+type ExportCreateErrorResponse struct {
+	Error string `json:"error" example:"エクスポートの作成に失敗しました"`
+}
@@ -3,6 +3,8 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 	"what-went-wrong-api/internal/models"
 	"what-went-wrong-api/internal/services"
@@ -12,6 +14,12 @@ import (
 	"gorm.io/gorm"
 )
 
+var (
+	errGoalLimitReached     = errors.New("goal limit reached")
+	errGoalOrderIncomplete  = errors.New("goal order does not list every goal")
+	errDailyExcuseCapTooLow = errors.New("a day has more excuses than the daily cap")
+)
+
 type GoalHandler struct {
 	db *gorm.DB
 }
@@ -22,10 +30,11 @@ func NewGoalHandler(db *gorm.DB) *GoalHandler {
 
 // GetGoals godoc
 // @Summary List goals
-// @Description Get all goals for the current user
+// @Description Get all goals for the current user. Archived goals are left out unless includeArchived is true.
 // @Tags goals
 // @Accept json
 // @Produce json
+// @Param includeArchived query bool false "Include archived goals"
 // @Success 200 {object} GetGoalsResponse
 // @Failure 401 {object} GoalUnauthorizedResponse
 // @Failure 500 {object} GoalFetchErrorResponse
@@ -39,23 +48,19 @@ func (h *GoalHandler) GetGoals(c *gin.Context) {
 	}
 	userID := userIDStr.(string)
 
+	query := h.db.Where("user_id = ?", userID)
+	if c.Query("includeArchived") != "true" {
+		query = query.Where("archived_at IS NULL")
+	}
 	var goals []models.Goal
-	if err := h.db.Where("user_id = ?", userID).Order("\"order\" asc, created_at desc").Find(&goals).Error; err != nil {
+	if err := query.Order("\"order\" asc, created_at desc").Find(&goals).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の取得に失敗しました"})
 		return
 	}
 
 	res := GetGoalsResponse{Goals: make([]GoalResponse, len(goals))}
 	for i, g := range goals {
-		res.Goals[i] = GoalResponse{
-			ID:                  g.ID.String(),
-			Title:               g.Title,
-			NotificationTime:    g.NotificationTime,
-			NotificationEnabled: g.NotificationEnabled,
-			Order:               g.Order,
-			CreatedAt:           g.CreatedAt,
-			UpdatedAt:           g.UpdatedAt,
-		}
+		res.Goals[i] = mapToGoalResponse(g)
 	}
 	c.JSON(http.StatusOK, res)
 }
@@ -98,21 +103,13 @@ func (h *GoalHandler) GetGoal(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, CreateGoalResponse{
-		Goal: GoalResponse{
-			ID:                  goal.ID.String(),
-			Title:               goal.Title,
-			NotificationTime:    goal.NotificationTime,
-			NotificationEnabled: goal.NotificationEnabled,
-			Order:               goal.Order,
-			CreatedAt:           goal.CreatedAt,
-			UpdatedAt:           goal.UpdatedAt,
-		},
+		Goal: mapToGoalResponse(goal),
 	})
 }
 
 // PostGoals godoc
 // @Summary Create goal
-// @Description Create a new goal. Checks for plan limits.
+// @Description Create a new goal. Checks for plan limits; archived goals do not count.
 // @Tags goals
 // @Accept json
 // @Produce json
@@ -120,7 +117,7 @@ func (h *GoalHandler) GetGoal(c *gin.Context) {
 // @Success 200 {object} CreateGoalResponse
 // @Failure 400 {object} GoalValidationErrorResponse
 // @Failure 401 {object} GoalUnauthorizedResponse
-// @Failure 403 {object} GoalLimitReachedResponse "Forbidden if max goals reached"
+// @Failure 403 {object} GoalLimitReachedResponse "Forbidden if max goals reached or the color is premium only"
 // @Failure 500 {object} GoalCreateErrorResponse
 // @Security BearerAuth
 // @Router /goals [post]
@@ -140,49 +137,49 @@ func (h *GoalHandler) PostGoals(c *gin.Context) {
 	}
 	entitlements := entitlementsInterface.(services.Entitlements)
 
-	var currentCount int64
-	if err := h.db.Model(&models.Goal{}).Where("user_id = ?", userID).Count(&currentCount).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標数の取得に失敗しました"})
-		return
-	}
-
-	if int(currentCount) >= entitlements.MaxGoals {
-		c.JSON(http.StatusForbidden, gin.H{"error": "プランの目標作成数上限に達しました"})
-		return
-	}
-
 	var req CreateGoalRequest
 	if err := c.BindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
 		return
 	}
-
-	// Calculate order (simple: put at end)
-	// Or just default to 0
+	if req.Color != nil && *req.Color != "" {
+		if ok, status, message := checkGoalColor(*req.Color, entitlements); !ok {
+			c.JSON(status, gin.H{"error": message})
+			return
+		}
+	}
+	if !isValidGoalPeriod(dateOrNil(req.StartDate), dateOrNil(req.EndDate)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "終了日は開始日以降の日付にしてください"})
+		return
+	}
 
 	newGoal := models.Goal{
 		UserID:              userID,
 		Title:               req.Title,
 		NotificationTime:    req.NotificationTime,
 		NotificationEnabled: req.NotificationEnabled,
-		Order:               int(currentCount) + 1, // Simple default order
+		Icon:                nilIfEmpty(req.Icon),
+		Color:               nilIfEmpty(req.Color),
+		CoverImageURL:       nilIfEmpty(req.CoverImageURL),
+		StartDate:           dateOrNil(req.StartDate),
+		EndDate:             dateOrNil(req.EndDate),
+		MaxExcusesPerDay:    1,
+	}
+	if req.MaxExcusesPerDay != nil {
+		newGoal.MaxExcusesPerDay = *req.MaxExcusesPerDay
 	}
 
-	if err := h.db.Create(&newGoal).Error; err != nil {
+	if err := createGoal(h.db, &newGoal, entitlements); err != nil {
+		if errors.Is(err, errGoalLimitReached) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "プランの目標作成数上限に達しました"})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の作成に失敗しました"})
 		return
 	}
 
 	c.JSON(http.StatusCreated, CreateGoalResponse{
-		Goal: GoalResponse{
-			ID:                  newGoal.ID.String(),
-			Title:               newGoal.Title,
-			NotificationTime:    newGoal.NotificationTime,
-			NotificationEnabled: newGoal.NotificationEnabled,
-			Order:               newGoal.Order,
-			CreatedAt:           newGoal.CreatedAt,
-			UpdatedAt:           newGoal.UpdatedAt,
-		},
+		Goal: mapToGoalResponse(newGoal),
 	})
 }
 
@@ -196,7 +193,9 @@ func (h *GoalHandler) PostGoals(c *gin.Context) {
 // @Success 200 {object} CreateGoalResponse
 // @Failure 400 {object} GoalValidationErrorResponse
 // @Failure 401 {object} GoalUnauthorizedResponse
+// @Failure 403 {object} GoalPremiumColorResponse
 // @Failure 404 {object} GoalNotFoundErrorResponse
+// @Failure 409 {object} GoalDailyExcuseCapResponse "maxExcusesPerDay is lower than the excuses of a day"
 // @Failure 500 {object} GoalUpdateErrorResponse
 // @Security BearerAuth
 // @Router /goals/{id} [patch]
@@ -210,6 +209,13 @@ func (h *GoalHandler) PatchGoal(c *gin.Context) {
 	userIDStr, _ := c.Get("userID")
 	userID := userIDStr.(string)
 
+	entitlementsInterface, exists := c.Get("entitlements")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "プラン情報の取得に失敗しました"})
+		return
+	}
+	entitlements := entitlementsInterface.(services.Entitlements)
+
 	var goal models.Goal
 	if err := h.db.First(&goal, "id = ? AND user_id = ?", goalID, userID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -225,6 +231,12 @@ func (h *GoalHandler) PatchGoal(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
 		return
 	}
+	if req.Color != nil && *req.Color != "" {
+		if ok, status, message := checkGoalColor(*req.Color, entitlements); !ok {
+			c.JSON(status, gin.H{"error": message})
+			return
+		}
+	}
 
 	if req.Title != nil {
 		goal.Title = *req.Title
@@ -235,28 +247,140 @@ func (h *GoalHandler) PatchGoal(c *gin.Context) {
 	if req.NotificationEnabled != nil {
 		goal.NotificationEnabled = *req.NotificationEnabled
 	}
+	if req.Icon != nil {
+		goal.Icon = nilIfEmpty(req.Icon)
+	}
+	if req.Color != nil {
+		goal.Color = nilIfEmpty(req.Color)
+	}
+	if req.CoverImageURL != nil {
+		goal.CoverImageURL = nilIfEmpty(req.CoverImageURL)
+	}
+	if req.StartDate != nil {
+		goal.StartDate = dateOrNil(req.StartDate)
+	}
+	endDateChanged := req.EndDate != nil && !equalDatePtr(goal.EndDate, dateOrNil(req.EndDate))
+	if req.EndDate != nil {
+		goal.EndDate = dateOrNil(req.EndDate)
+	}
+	capLowered := req.MaxExcusesPerDay != nil && *req.MaxExcusesPerDay < goal.MaxExcusesPerDay
+	if req.MaxExcusesPerDay != nil {
+		goal.MaxExcusesPerDay = *req.MaxExcusesPerDay
+	}
+	if !isValidGoalPeriod(goal.StartDate, goal.EndDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "終了日は開始日以降の日付にしてください"})
+		return
+	}
 	goal.UpdatedAt = time.Now()
 
-	if err := h.db.Save(&goal).Error; err != nil {
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if capLowered {
+			if err := checkDailyExcuseCap(tx, goal.UserID, goal.ID, goal.MaxExcusesPerDay); err != nil {
+				return err
+			}
+		}
+		// The summary belongs to the previous period; a new one is written when the new period ends
+		if endDateChanged {
+			if err := tx.Where("goal_id = ?", goal.ID).Delete(&models.GoalSummary{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Save(&goal).Error
+	})
+	if errors.Is(err, errDailyExcuseCapTooLow) {
+		c.JSON(http.StatusConflict, gin.H{"error": dailyExcuseCapTooLowMessage})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の更新に失敗しました"})
 		return
 	}
 
 	c.JSON(http.StatusOK, CreateGoalResponse{
-		Goal: GoalResponse{
-			ID:                  goal.ID.String(),
-			Title:               goal.Title,
-			NotificationTime:    goal.NotificationTime,
-			NotificationEnabled: goal.NotificationEnabled,
-			Order:               goal.Order,
-			CreatedAt:           goal.CreatedAt,
-			UpdatedAt:           goal.UpdatedAt,
-		},
+		Goal: mapToGoalResponse(goal),
 	})
 }
 
+// PutGoalsOrder godoc
+// @Summary Reorder goals
+// @Description Set the order of the goals shown by GET /goals. goalIds must list every one of them exactly once, first goal first; archived goals keep their order.
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Param request body ReorderGoalsRequest true "Request body"
+// @Success 200 {object} GetGoalsResponse
+// @Failure 400 {object} GoalOrderIncompleteResponse
+// @Failure 401 {object} GoalUnauthorizedResponse
+// @Failure 500 {object} GoalUpdateErrorResponse
+// @Security BearerAuth
+// @Router /goals/order [put]
+func (h *GoalHandler) PutGoalsOrder(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var req ReorderGoalsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+
+	var goals []models.Goal
+	err := h.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND archived_at IS NULL", userID).Find(&goals).Error; err != nil {
+			return err
+		}
+		// IDs are unique, so matching every goal means the list is exactly the user's goals
+		positions := make(map[string]int, len(req.GoalIDs))
+		for i, id := range req.GoalIDs {
+			positions[strings.ToLower(id)] = i
+		}
+		if len(goals) != len(req.GoalIDs) {
+			return errGoalOrderIncomplete
+		}
+		for _, g := range goals {
+			if _, ok := positions[g.ID.String()]; !ok {
+				return errGoalOrderIncomplete
+			}
+		}
+
+		now := time.Now()
+		for i := range goals {
+			order := positions[goals[i].ID.String()] + 1
+			if goals[i].Order == order {
+				continue
+			}
+			goals[i].Order = order
+			goals[i].UpdatedAt = now
+			if err := tx.Save(&goals[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errGoalOrderIncomplete) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "すべての目標を指定してください"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の更新に失敗しました"})
+		return
+	}
+
+	sort.Slice(goals, func(i, j int) bool { return goals[i].Order < goals[j].Order })
+	res := GetGoalsResponse{Goals: make([]GoalResponse, len(goals))}
+	for i, g := range goals {
+		res.Goals[i] = mapToGoalResponse(g)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
 // DeleteGoal godoc
 // @Summary Delete goal
+// @Description Move the goal and its excuses to the trash. They can be restored until they are purged.
 // @Tags goals
 // @Accept json
 // @Produce json
@@ -278,10 +402,6 @@ func (h *GoalHandler) DeleteGoal(c *gin.Context) {
 	userIDStr, _ := c.Get("userID")
 	userID := userIDStr.(string)
 
-	// Transaction to delete associated excuses if necessary
-	// Assuming cascade delete is configured in DB or we handle it here
-	// Spec says: Goal削除＋紐づくExcuseEntry削除
-
 	err = h.db.Transaction(func(tx *gorm.DB) error {
 		// Verify ownership
 		var goal models.Goal
@@ -289,13 +409,7 @@ func (h *GoalHandler) DeleteGoal(c *gin.Context) {
 			return err
 		}
 
-		// Delete excuses
-		if err := tx.Where("goal_id = ?", goalID).Delete(&models.ExcuseEntry{}).Error; err != nil {
-			return err
-		}
-
-		// Delete goal
-		return tx.Delete(&goal).Error
+		return deleteGoal(tx, goal)
 	})
 
 	if err != nil {
@@ -309,3 +423,293 @@ func (h *GoalHandler) DeleteGoal(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// PostArchiveGoal godoc
+// @Summary Archive goal
+// @Description Hide the goal from GET /goals and stop its reminders. Its excuses can still be viewed, but no new ones can be saved. Archived goals do not count toward the plan's goal limit.
+// @Tags goals
+// @Produce json
+// @Param id path string true "Goal ID" format:uuid
+// @Success 200 {object} CreateGoalResponse
+// @Failure 400 {object} GoalValidationErrorResponse
+// @Failure 401 {object} GoalUnauthorizedResponse
+// @Failure 404 {object} GoalNotFoundErrorResponse
+// @Failure 500 {object} GoalUpdateErrorResponse
+// @Security BearerAuth
+// @Router /goals/{id}/archive [post]
+func (h *GoalHandler) PostArchiveGoal(c *gin.Context) {
+	goalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var goal models.Goal
+	if err := h.db.First(&goal, "id = ? AND user_id = ?", goalID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "目標が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の取得に失敗しました"})
+		return
+	}
+
+	// Archiving again keeps the original date
+	if goal.ArchivedAt == nil {
+		now := time.Now()
+		goal.ArchivedAt = &now
+		goal.UpdatedAt = now
+		if err := h.db.Save(&goal).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の更新に失敗しました"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, CreateGoalResponse{
+		Goal: mapToGoalResponse(goal),
+	})
+}
+
+// PostUnarchiveGoal godoc
+// @Summary Unarchive goal
+// @Description Bring an archived goal back. It counts toward the plan's goal limit again (403 when the limit is reached).
+// @Tags goals
+// @Produce json
+// @Param id path string true "Goal ID" format:uuid
+// @Success 200 {object} CreateGoalResponse
+// @Failure 400 {object} GoalValidationErrorResponse
+// @Failure 401 {object} GoalUnauthorizedResponse
+// @Failure 403 {object} GoalLimitReachedResponse
+// @Failure 404 {object} GoalNotFoundErrorResponse
+// @Failure 500 {object} GoalUpdateErrorResponse
+// @Security BearerAuth
+// @Router /goals/{id}/unarchive [post]
+func (h *GoalHandler) PostUnarchiveGoal(c *gin.Context) {
+	goalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	entitlementsInterface, exists := c.Get("entitlements")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "プラン情報の取得に失敗しました"})
+		return
+	}
+	entitlements := entitlementsInterface.(services.Entitlements)
+
+	var goal models.Goal
+	if err := h.db.First(&goal, "id = ? AND user_id = ?", goalID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "目標が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の取得に失敗しました"})
+		return
+	}
+
+	if goal.ArchivedAt != nil {
+		count, err := countActiveGoals(h.db, userID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "目標数の取得に失敗しました"})
+			return
+		}
+		if int(count) >= entitlements.MaxGoals {
+			c.JSON(http.StatusForbidden, gin.H{"error": "プランの目標作成数上限に達しました"})
+			return
+		}
+
+		goal.ArchivedAt = nil
+		goal.UpdatedAt = time.Now()
+		if err := h.db.Save(&goal).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の更新に失敗しました"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, CreateGoalResponse{
+		Goal: mapToGoalResponse(goal),
+	})
+}
+
+// GetGoalSummary godoc
+// @Summary Get goal summary
+// @Description Get the result of a goal with an end date: days in the period, days with an excuse and the success rate. Paused days are counted apart and left out of the rate. It is written shortly after the end date passes in the user's time zone, when the goal is archived.
+// @Tags goals
+// @Produce json
+// @Param id path string true "Goal ID" format:uuid
+// @Success 200 {object} GoalSummaryResponse
+// @Failure 400 {object} GoalValidationErrorResponse
+// @Failure 401 {object} GoalUnauthorizedResponse
+// @Failure 404 {object} GoalSummaryNotFoundResponse "The goal does not exist or its period has not ended yet"
+// @Failure 500 {object} GoalFetchErrorResponse
+// @Security BearerAuth
+// @Router /goals/{id}/summary [get]
+func (h *GoalHandler) GetGoalSummary(c *gin.Context) {
+	goalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var goal models.Goal
+	if err := h.db.First(&goal, "id = ? AND user_id = ?", goalID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "目標が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の取得に失敗しました"})
+		return
+	}
+
+	var summary models.GoalSummary
+	if err := h.db.First(&summary, "goal_id = ?", goal.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "目標のまとめはまだありません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, GoalSummaryResponse{
+		GoalID:      summary.GoalID.String(),
+		StartDate:   string(summary.StartDate),
+		EndDate:     string(summary.EndDate),
+		Days:        summary.Days,
+		PausedDays:  summary.PausedDays,
+		ExcuseDays:  summary.ExcuseDays,
+		SuccessRate: summary.SuccessRate,
+		CreatedAt:   summary.CreatedAt,
+	})
+}
+
+// deleteGoal moves the goal and its excuses to the trash, leaving tombstones for delta sync.
+// The goal goes first, so that the excuses trashed with it are the ones deleted at or after it.
+func deleteGoal(tx *gorm.DB, goal models.Goal) error {
+	if err := tx.Delete(&goal).Error; err != nil {
+		return err
+	}
+	if err := tx.Create(&models.Tombstone{UserID: goal.UserID, EntityType: "goal", EntityID: goal.ID}).Error; err != nil {
+		return err
+	}
+
+	var excuses []models.ExcuseEntry
+	if err := tx.Where("goal_id = ?", goal.ID).Find(&excuses).Error; err != nil {
+		return err
+	}
+	for _, excuse := range excuses {
+		if err := deleteExcuseEntry(tx, excuse); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const dailyExcuseCapTooLowMessage = "1日の上限より多く言い訳を保存した日があるため、上限を下げられません"
+
+// checkDailyExcuseCap returns errDailyExcuseCapTooLow when a day of the goal already has more excuses
+// than maxPerDay. The user's lock keeps new excuses out until tx commits.
+func checkDailyExcuseCap(tx *gorm.DB, userID string, goalID uuid.UUID, maxPerDay int) error {
+	if err := models.LockUserChanges(tx, userID); err != nil {
+		return err
+	}
+	count, err := maxDailyExcuseCount(tx, goalID)
+	if err != nil {
+		return err
+	}
+	if count > maxPerDay {
+		return errDailyExcuseCapTooLow
+	}
+	return nil
+}
+
+// createGoal saves goal at the end of the user's list, or returns errGoalLimitReached when the plan's
+// MaxGoals is reached.
+func createGoal(db *gorm.DB, goal *models.Goal, entitlements services.Entitlements) error {
+	count, err := countActiveGoals(db, goal.UserID)
+	if err != nil {
+		return err
+	}
+	if int(count) >= entitlements.MaxGoals {
+		return errGoalLimitReached
+	}
+	goal.Order = int(count) + 1
+	return db.Create(goal).Error
+}
+
+// countActiveGoals counts the goals that count toward the plan's MaxGoals, which leaves out archived ones.
+func countActiveGoals(db *gorm.DB, userID string) (int64, error) {
+	var count int64
+	err := db.Model(&models.Goal{}).Where("user_id = ? AND archived_at IS NULL", userID).Count(&count).Error
+	return count, err
+}
+
+// isValidGoalPeriod reports whether end, when both are set, is not before start.
+func isValidGoalPeriod(start, end *models.Date) bool {
+	return start == nil || end == nil || *start <= *end
+}
+
+func equalDatePtr(a, b *models.Date) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// dateOrNil returns the date of an optional request field, nil when it is omitted or empty.
+func dateOrNil(s *string) *models.Date {
+	return models.DatePtr(nilIfEmpty(s))
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func nilIfEmpty(s *string) *string {
+	if s == nil || *s == "" {
+		return nil
+	}
+	return s
+}
+
+func mapToGoalResponse(g models.Goal) GoalResponse {
+	return GoalResponse{
+		ID:                  g.ID.String(),
+		Title:               g.Title,
+		NotificationTime:    g.NotificationTime,
+		NotificationEnabled: g.NotificationEnabled,
+		Icon:                g.Icon,
+		Color:               g.Color,
+		CoverImageURL:       g.CoverImageURL,
+		Order:               g.Order,
+		Version:             g.Version,
+		StartDate:           g.StartDate.StringPtr(),
+		EndDate:             g.EndDate.StringPtr(),
+		MaxExcusesPerDay:    g.MaxExcusesPerDay,
+		ArchivedAt:          g.ArchivedAt,
+		CreatedAt:           g.CreatedAt,
+		UpdatedAt:           g.UpdatedAt,
+	}
+}
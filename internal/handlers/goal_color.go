@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type goalColor struct {
+	Key     string
+	Hex     string
+	Palette string
+}
+
+// premiumPalettes are the palettes that need CanUsePremiumPalettes.
+var premiumPalettes = map[string]bool{"wa": true}
+
+// goalColors lists the palette keys accepted as Goal.Color, in display order. Clients render them from
+// the hex value, so keys must never be renamed once released.
+var goalColors = []goalColor{
+	{Key: "red", Hex: "#E5484D", Palette: "basic"},
+	{Key: "orange", Hex: "#F76B15", Palette: "basic"},
+	{Key: "yellow", Hex: "#FFC53D", Palette: "basic"},
+	{Key: "green", Hex: "#30A46C", Palette: "basic"},
+	{Key: "teal", Hex: "#12A594", Palette: "basic"},
+	{Key: "blue", Hex: "#0090FF", Palette: "basic"},
+	{Key: "purple", Hex: "#8E4EC6", Palette: "basic"},
+	{Key: "pink", Hex: "#D6409F", Palette: "basic"},
+	{Key: "gray", Hex: "#8B8D98", Palette: "basic"},
+	{Key: "sakura", Hex: "#F4A7B9", Palette: "wa"},
+	{Key: "momiji", Hex: "#C8413B", Palette: "wa"},
+	{Key: "yamabuki", Hex: "#F8B500", Palette: "wa"},
+	{Key: "matcha", Hex: "#8DB255", Palette: "wa"},
+	{Key: "ai", Hex: "#264D8C", Palette: "wa"},
+	{Key: "fuji", Hex: "#A59ACA", Palette: "wa"},
+	{Key: "sumi", Hex: "#3A3A3A", Palette: "wa"},
+}
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// checkGoalColor reports whether color may be set on a goal: a palette key or a "#RRGGBB" hex value.
+// The hex value of a palette color counts as that color. Premium palettes and other hex values need
+// CanUsePremiumPalettes, so that free plans cannot pick premium colors by their hex value.
+// On rejection it returns the status and message to respond with.
+func checkGoalColor(color string, entitlements services.Entitlements) (bool, int, string) {
+	isHex := hexColorPattern.MatchString(color)
+	for _, gc := range goalColors {
+		if gc.Key != color && !(isHex && strings.EqualFold(gc.Hex, color)) {
+			continue
+		}
+		if premiumPalettes[gc.Palette] && !entitlements.CanUsePremiumPalettes {
+			return false, http.StatusForbidden, "プレミアムカラーを利用するにはプレミアムプランが必要です"
+		}
+		return true, 0, ""
+	}
+	if isHex {
+		if !entitlements.CanUsePremiumPalettes {
+			return false, http.StatusForbidden, "カスタムカラーを利用するにはプレミアムプランが必要です"
+		}
+		return true, 0, ""
+	}
+	return false, http.StatusBadRequest, "色はパレットのキーか #RRGGBB 形式で指定してください"
+}
+
+// GetGoalColors godoc
+// @Summary List goal colors
+// @Description List the palette keys usable as a goal's color. Colors of premium palettes can be set only with the premium plan.
+// @Tags goals
+// @Produce json
+// @Success 200 {object} GetGoalColorsResponse
+// @Security BearerAuth
+// @Router /goal-colors [get]
+func (h *GoalHandler) GetGoalColors(c *gin.Context) {
+	res := GetGoalColorsResponse{Colors: make([]GoalColorResponse, len(goalColors))}
+	for i, gc := range goalColors {
+		res.Colors[i] = GoalColorResponse{
+			Key:       gc.Key,
+			Hex:       gc.Hex,
+			Palette:   gc.Palette,
+			IsPremium: premiumPalettes[gc.Palette],
+		}
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -286,3 +286,231 @@ func TestGoal_InvalidUUID(t *testing.T) {
 		})
 	}
 }
+
+func TestArchiveGoal(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewGoalHandler(db)
+
+	userID := "auth0|test"
+	goal := models.Goal{UserID: userID, Title: "Finished"}
+	db.Create(&goal)
+
+	call := func(handle gin.HandlerFunc, method string, query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", services.Entitlements{MaxGoals: 1})
+		c.Params = gin.Params{{Key: "id", Value: goal.ID.String()}}
+		c.Request, _ = http.NewRequest(method, "/goals"+query, nil)
+		handle(c)
+		return w
+	}
+
+	w := call(handler.PostArchiveGoal, "POST", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp CreateGoalResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.NotNil(t, resp.Goal.ArchivedAt)
+
+	// Hidden from the list by default
+	var list GetGoalsResponse
+	w = call(handler.GetGoals, "GET", "")
+	json.Unmarshal(w.Body.Bytes(), &list)
+	assert.Empty(t, list.Goals)
+	w = call(handler.GetGoals, "GET", "?includeArchived=true")
+	json.Unmarshal(w.Body.Bytes(), &list)
+	assert.Len(t, list.Goals, 1)
+
+	// The archived goal leaves room for a new one, which then blocks unarchiving
+	db.Create(&models.Goal{UserID: userID, Title: "New"})
+	w = call(handler.PostUnarchiveGoal, "POST", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	db.Where("title = ?", "New").Delete(&models.Goal{})
+	w = call(handler.PostUnarchiveGoal, "POST", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Nil(t, resp.Goal.ArchivedAt)
+}
+
+func TestPutGoalsOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewGoalHandler(db)
+
+	userID := "auth0|test"
+	var ids []string
+	for i := 0; i < 3; i++ {
+		goal := models.Goal{UserID: userID, Title: "Goal", Order: i + 1}
+		db.Create(&goal)
+		ids = append(ids, goal.ID.String())
+	}
+	other := models.Goal{UserID: "auth0|other", Title: "Other"}
+	db.Create(&other)
+
+	tests := []struct {
+		name           string
+		goalIDs        []string
+		expectedStatus int
+	}{
+		{name: "Missing", goalIDs: []string{ids[2], ids[1]}, expectedStatus: http.StatusBadRequest},
+		{name: "Duplicate", goalIDs: []string{ids[2], ids[2], ids[1]}, expectedStatus: http.StatusBadRequest},
+		{name: "OtherUsersGoal", goalIDs: []string{ids[2], ids[1], other.ID.String()}, expectedStatus: http.StatusBadRequest},
+		{name: "Reversed", goalIDs: []string{ids[2], ids[1], ids[0]}, expectedStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("userID", userID)
+			jsonBytes, _ := json.Marshal(ReorderGoalsRequest{GoalIDs: tt.goalIDs})
+			c.Request, _ = http.NewRequest("PUT", "/goals/order", bytes.NewBuffer(jsonBytes))
+
+			handler.PutGoalsOrder(c)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+
+	var goal models.Goal
+	db.First(&goal, "id = ?", ids[2])
+	assert.Equal(t, 1, goal.Order)
+	db.First(&goal, "id = ?", ids[0])
+	assert.Equal(t, 3, goal.Order)
+}
+
+func TestCheckGoalColor(t *testing.T) {
+	free := services.Entitlements{}
+	premium := services.Entitlements{CanUsePremiumPalettes: true}
+
+	tests := []struct {
+		name           string
+		color          string
+		entitlements   services.Entitlements
+		expectedStatus int
+	}{
+		{name: "CustomHexOnFree", color: "#ff8800", entitlements: free, expectedStatus: http.StatusForbidden},
+		{name: "CustomHex", color: "#ff8800", entitlements: premium},
+		{name: "BasicPalette", color: "blue", entitlements: free},
+		{name: "BasicHexOnFree", color: "#0090ff", entitlements: free},
+		{name: "PremiumHexOnFree", color: "#F4A7B9", entitlements: free, expectedStatus: http.StatusForbidden},
+		{name: "PremiumHex", color: "#f4a7b9", entitlements: premium},
+		{name: "PremiumPaletteOnFree", color: "sakura", entitlements: free, expectedStatus: http.StatusForbidden},
+		{name: "PremiumPalette", color: "sakura", entitlements: premium},
+		{name: "ShortHex", color: "#f80", entitlements: premium, expectedStatus: http.StatusBadRequest},
+		{name: "UnknownKey", color: "rainbow", entitlements: premium, expectedStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, status, _ := checkGoalColor(tt.color, tt.entitlements)
+			assert.Equal(t, tt.expectedStatus == 0, ok)
+			assert.Equal(t, tt.expectedStatus, status)
+		})
+	}
+}
+
+func TestGoalPeriod(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewGoalHandler(db)
+
+	userID := "auth0|test"
+	startDate, endDate := "2025-01-01", "2025-01-30"
+	goal := models.Goal{UserID: userID, Title: "30-day challenge", StartDate: models.DatePtr(&startDate), EndDate: models.DatePtr(&endDate)}
+	db.Create(&goal)
+
+	call := func(handle gin.HandlerFunc, method string, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", services.Entitlements{MaxGoals: 3})
+		c.Params = gin.Params{{Key: "id", Value: goal.ID.String()}}
+		c.Request, _ = http.NewRequest(method, "/goals/"+goal.ID.String(), bytes.NewBufferString(body))
+		handle(c)
+		return w
+	}
+
+	w := call(handler.PatchGoal, "PATCH", `{"endDate": "2024-12-31"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = call(handler.GetGoalSummary, "GET", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	db.Create(&models.GoalSummary{GoalID: goal.ID, UserID: userID, StartDate: models.Date(startDate), EndDate: models.Date(endDate), Days: 30, ExcuseDays: 6, SuccessRate: 0.8})
+	w = call(handler.GetGoalSummary, "GET", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var summary GoalSummaryResponse
+	json.Unmarshal(w.Body.Bytes(), &summary)
+	assert.Equal(t, 30, summary.Days)
+	assert.Equal(t, 0.8, summary.SuccessRate)
+
+	// Extending the period discards the summary of the previous one
+	w = call(handler.PatchGoal, "PATCH", `{"endDate": "2025-02-28"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp CreateGoalResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, "2025-02-28", *resp.Goal.EndDate)
+	w = call(handler.GetGoalSummary, "GET", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Resending the end date read back from the database keeps the summary
+	db.Create(&models.GoalSummary{GoalID: goal.ID, UserID: userID, StartDate: models.Date(startDate), EndDate: "2025-02-28", Days: 59, SuccessRate: 1})
+	w = call(handler.PatchGoal, "PATCH", `{"title": "59-day challenge", "endDate": "2025-02-28"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = call(handler.GetGoalSummary, "GET", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// A one-day period, with the end date coming from the database
+	w = call(handler.PatchGoal, "PATCH", `{"startDate": "2025-02-28"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, "2025-02-28", *resp.Goal.StartDate)
+}
+
+func TestPatchGoal_LowerDailyCap(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewGoalHandler(db)
+
+	userID := "auth0|test"
+	goal := models.Goal{UserID: userID, Title: "Drink water 3x a day", MaxExcusesPerDay: 3}
+	db.Create(&goal)
+	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-01", Slot: 0, ExcuseText: "Morning"})
+	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-01", Slot: 1, ExcuseText: "Noon"})
+	trashed := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-01", Slot: 2, ExcuseText: "Evening"}
+	db.Create(&trashed)
+	db.Delete(&trashed)
+
+	patch := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", services.Entitlements{MaxGoals: 3})
+		c.Params = gin.Params{{Key: "id", Value: goal.ID.String()}}
+		c.Request, _ = http.NewRequest("PATCH", "/goals/"+goal.ID.String(), bytes.NewBufferString(body))
+		handler.PatchGoal(c)
+		return w
+	}
+
+	w := patch(`{"maxExcusesPerDay": 1}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	var saved models.Goal
+	db.First(&saved, "id = ?", goal.ID)
+	assert.Equal(t, 3, saved.MaxExcusesPerDay)
+
+	// The trash does not count
+	w = patch(`{"maxExcusesPerDay": 2}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp CreateGoalResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, 2, resp.Goal.MaxExcusesPerDay)
+}
@@ -3,13 +3,21 @@ package handlers
 import "time"
 
 type GoalResponse struct {
-	ID                  string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Title               string    `json:"title" example:"本を10ページ読む"`
-	NotificationTime    *string   `json:"notificationTime,omitempty" example:"20:00"`
-	NotificationEnabled bool      `json:"notificationEnabled" example:"true"`
-	Order               int       `json:"order" example:"1"`
-	CreatedAt           time.Time `json:"createdAt"`
-	UpdatedAt           time.Time `json:"updatedAt"`
+	ID                  string     `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Title               string     `json:"title" example:"本を10ページ読む"`
+	NotificationTime    *string    `json:"notificationTime,omitempty" example:"20:00"`
+	NotificationEnabled bool       `json:"notificationEnabled" example:"true"`
+	Icon                *string    `json:"icon,omitempty" example:"📚"`
+	Color               *string    `json:"color,omitempty" example:"blue"`
+	CoverImageURL       *string    `json:"coverImageUrl,omitempty" example:"https://example.com/covers/books.jpg"`
+	Order               int        `json:"order" example:"1"`
+	Version             int        `json:"version" example:"1"`
+	StartDate           *string    `json:"startDate,omitempty" format:"date" example:"2023-10-01"`
+	EndDate             *string    `json:"endDate,omitempty" format:"date" example:"2023-10-30"`
+	MaxExcusesPerDay    int        `json:"maxExcusesPerDay" example:"1"`
+	ArchivedAt          *time.Time `json:"archivedAt,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
 }
 
 type GetGoalsResponse struct {
@@ -20,22 +28,79 @@ type CreateGoalRequest struct {
 	Title               string  `json:"title" binding:"required,max=200" example:"本を10ページ読む"`
 	NotificationTime    *string `json:"notificationTime" example:"20:00"`
 	NotificationEnabled bool    `json:"notificationEnabled" example:"true"`
+	Icon                *string `json:"icon" binding:"omitempty,max=16" example:"📚"`
+	Color               *string `json:"color" binding:"omitempty,max=32" example:"blue"` // Palette key (GET /goal-colors) or "#RRGGBB", which needs premium unless it is a basic palette color
+	CoverImageURL       *string `json:"coverImageUrl" binding:"omitempty,max=2048,url,startswith=https://" example:"https://example.com/covers/books.jpg"`
+	StartDate           *string `json:"startDate" binding:"omitempty,datetime=2006-01-02" format:"date" example:"2023-10-01"`
+	EndDate             *string `json:"endDate" binding:"omitempty,datetime=2006-01-02" format:"date" example:"2023-10-30"`
+	MaxExcusesPerDay    *int    `json:"maxExcusesPerDay" binding:"omitempty,min=1,max=10" example:"3"` // Default 1
 }
 
 type CreateGoalResponse struct {
 	Goal GoalResponse `json:"goal"`
 }
 
+type ReorderGoalsRequest struct {
+	GoalIDs []string `json:"goalIds" binding:"required,min=1,max=1000,unique,dive,uuid" example:"550e8400-e29b-41d4-a716-446655440001,550e8400-e29b-41d4-a716-446655440000"`
+}
+
 type UpdateGoalRequest struct {
 	Title               *string `json:"title" binding:"omitempty,max=200" example:"本を20ページ読む"`
 	NotificationTime    *string `json:"notificationTime" example:"21:00"`
 	NotificationEnabled *bool   `json:"notificationEnabled" example:"false"`
+	// "" clears the appearance fields below
+	Icon          *string `json:"icon" binding:"omitempty,max=16" example:"🏃"`
+	Color         *string `json:"color" binding:"omitempty,max=32" example:"#FF8800"`
+	CoverImageURL *string `json:"coverImageUrl" binding:"omitempty,max=2048,url,startswith=https://" example:"https://example.com/covers/run.jpg"`
+	// "" clears the period; changing endDate discards the summary of the previous period
+	StartDate *string `json:"startDate" binding:"omitempty,datetime=2006-01-02" format:"date" example:"2023-10-01"`
+	EndDate   *string `json:"endDate" binding:"omitempty,datetime=2006-01-02" format:"date" example:"2023-10-30"`
+	// Lowering it keeps the excuses already saved
+	MaxExcusesPerDay *int `json:"maxExcusesPerDay" binding:"omitempty,min=1,max=10" example:"1"`
+}
+
+type GoalSummaryResponse struct {
+	GoalID      string    `json:"goalId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	StartDate   string    `json:"startDate" format:"date" example:"2023-10-01"`
+	EndDate     string    `json:"endDate" format:"date" example:"2023-10-30"`
+	Days        int       `json:"days" example:"30"` // Paused days excluded
+	PausedDays  int       `json:"pausedDays" example:"0"`
+	ExcuseDays  int       `json:"excuseDays" example:"6"`
+	SuccessRate float64   `json:"successRate" example:"0.8"` // Share of days without an excuse, 0 to 1
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type GoalColorResponse struct {
+	Key       string `json:"key" example:"sakura"`
+	Hex       string `json:"hex" example:"#F4A7B9"`
+	Palette   string `json:"palette" example:"wa"`
+	IsPremium bool   `json:"isPremium" example:"true"`
+}
+
+type GetGoalColorsResponse struct {
+	Colors []GoalColorResponse `json:"colors"`
+}
+
+type GoalPremiumColorResponse struct {
+	Error string `json:"error" example:"プレミアムカラーを利用するにはプレミアムプランが必要です"`
 }
 
 type GoalLimitReachedResponse struct {
 	Error string `json:"error" example:"プランの目標作成数上限に達しました"`
 }
 
+type GoalDailyExcuseCapResponse struct {
+	Error string `json:"error" example:"1日の上限より多く言い訳を保存した日があるため、上限を下げられません"`
+}
+
+type GoalOrderIncompleteResponse struct {
+	Error string `json:"error" example:"すべての目標を指定してください"`
+}
+
+type GoalSummaryNotFoundResponse struct {
+	Error string `json:"error" example:"目標のまとめはまだありません"`
+}
+
 type GoalNotFoundErrorResponse struct {
 	Error string `json:"error" example:"目標が見つかりません"`
 }
@@ -0,0 +1,340 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	maxImportFileSize = 5 << 20
+	maxImportRows     = 5000
+	// Loop Habit Tracker has no notes, so missed days are imported with this text
+	loopMissedExcuse = "未達成（Loop Habit Trackerから取り込み）"
+)
+
+var (
+	errImportHeader = errors.New("missing required columns")
+	// Returned from the import transaction to roll back a dry run
+	errImportDryRun = errors.New("dry run")
+)
+
+type importRow struct {
+	Line       int
+	Date       string
+	GoalTitle  string
+	ExcuseText string
+}
+
+type ImportHandler struct {
+	db        *gorm.DB
+	moderator services.Moderator
+}
+
+func NewImportHandler(db *gorm.DB, moderator services.Moderator) *ImportHandler {
+	return &ImportHandler{db: db, moderator: moderator}
+}
+
+// PostMeImports godoc
+// @Summary Import excuse history
+// @Description Import excuses from a CSV file (columns date, goal, excuse; the CSV of the data export works as is) or the Checkmarks.csv of a Loop Habit Tracker export (missed days become excuses). Goals are matched by title and created when missing, within the plan's goal limit. Rows follow the rules of POST /goals/{goal_id}/excuses and overwrite the excuse of the same day. Rows that fail are reported and skipped. With dryRun=true nothing is saved.
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV file (max 5MB, 5000 rows)"
+// @Param format query string false "csv (default) or loop"
+// @Param dryRun query bool false "Preview without saving"
+// @Success 200 {object} ImportResponse
+// @Failure 400 {object} ImportValidationErrorResponse
+// @Failure 401 {object} ImportUnauthorizedResponse
+// @Failure 500 {object} ImportErrorResponse
+// @Security BearerAuth
+// @Router /me/imports [post]
+func (h *ImportHandler) PostMeImports(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	entitlementsInterface, exists := c.Get("entitlements")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "プラン情報の取得に失敗しました"})
+		return
+	}
+	entitlements := entitlementsInterface.(services.Entitlements)
+
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "loop" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	dryRun := c.Query("dryRun") == "true"
+
+	limitMultipartBody(c, maxImportFileSize)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		if isBodyTooLarge(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルサイズが大きすぎます"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルサイズが大きすぎます"})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルを読み込めませんでした"})
+		return
+	}
+	defer file.Close()
+
+	var rows []importRow
+	if format == "loop" {
+		rows, err = parseLoopCheckmarksCSV(file)
+	} else {
+		rows, err = parseExcuseCSV(file)
+	}
+	if err != nil {
+		if errors.Is(err, errImportHeader) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "必要な列がありません"})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ファイルを読み込めませんでした"})
+		return
+	}
+	if len(rows) > maxImportRows {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "行数が多すぎます"})
+		return
+	}
+
+	res := ImportResponse{DryRun: dryRun, Errors: []ImportRowError{}}
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		if err := h.importRows(c, tx, userID, entitlements, rows, &res); err != nil {
+			return err
+		}
+		if dryRun {
+			return errImportDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportDryRun) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "取り込みに失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// importRows saves rows with the same rules as PostExcuse, recording rejected rows in res.Errors.
+func (h *ImportHandler) importRows(c *gin.Context, tx *gorm.DB, userID string, entitlements services.Entitlements, rows []importRow, res *ImportResponse) error {
+	var goals []models.Goal
+	if err := tx.Where("user_id = ?", userID).Find(&goals).Error; err != nil {
+		return err
+	}
+	goalsByTitle := make(map[string]models.Goal, len(goals))
+	goalCount := 0
+	for _, g := range goals {
+		goalsByTitle[g.Title] = g
+		if g.ArchivedAt == nil {
+			goalCount++
+		}
+	}
+
+	// Goals created by the import start on their first imported day, so that the history is not before their creation
+	firstDates := map[string]string{}
+	for _, row := range rows {
+		if _, ok := goalsByTitle[row.GoalTitle]; ok || !isValidDate(row.Date) {
+			continue
+		}
+		if first, ok := firstDates[row.GoalTitle]; !ok || row.Date < first {
+			firstDates[row.GoalTitle] = row.Date
+		}
+	}
+
+	for _, row := range rows {
+		fail := func(message string) {
+			res.Errors = append(res.Errors, ImportRowError{Line: row.Line, Error: message})
+		}
+
+		if row.GoalTitle == "" || utf8.RuneCountInString(row.GoalTitle) > 200 {
+			fail("目標名は1〜200文字で指定してください")
+			continue
+		}
+		if row.ExcuseText == "" || utf8.RuneCountInString(row.ExcuseText) > 500 {
+			fail("言い訳は1〜500文字で指定してください")
+			continue
+		}
+		if !isValidDate(row.Date) {
+			fail("日付はYYYY-MM-DD形式の正しい日付で指定してください")
+			continue
+		}
+
+		goal, exists := goalsByTitle[row.GoalTitle]
+		if !exists {
+			if goalCount >= entitlements.MaxGoals {
+				fail("プランの目標作成数上限に達しました")
+				continue
+			}
+			createdAt, _ := time.ParseInLocation(dateLayout, firstDates[row.GoalTitle], userLocation(c))
+			goal = models.Goal{UserID: userID, Title: row.GoalTitle, Order: goalCount + 1, CreatedAt: createdAt}
+		}
+		if ok, _, message := checkExcuseDate(c, tx, goal, Date(row.Date), entitlements); !ok {
+			fail(message)
+			continue
+		}
+
+		moderation, err := h.moderator.Moderate(row.ExcuseText)
+		if err != nil {
+			return err
+		}
+		if moderation.Rejected {
+			fail("不適切な表現が含まれています")
+			continue
+		}
+
+		if !exists {
+			if err := tx.Create(&goal).Error; err != nil {
+				return err
+			}
+			goalsByTitle[goal.Title] = goal
+			goalCount++
+			res.GoalsCreated++
+		}
+
+		entry, created, err := saveExcuseEntry(tx, goal, models.ExcuseEntry{
+			UserID:     userID,
+			GoalID:     goal.ID,
+			Date:       models.Date(row.Date),
+			ExcuseText: moderation.Text,
+		})
+		if errors.Is(err, errDailyExcuseLimitReached) {
+			fail(dailyExcuseLimitMessage(goal))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if moderation.Flagged {
+			if err := enqueueModerationReview(tx, userID, "import", &entry.ID, moderation); err != nil {
+				return err
+			}
+		}
+		if created {
+			res.ExcusesCreated++
+		} else {
+			res.ExcusesUpdated++
+		}
+	}
+	return nil
+}
+
+func newImportCSVReader(r io.Reader) *csv.Reader {
+	br := bufio.NewReader(r)
+	// Spreadsheet apps often save CSV with a BOM
+	if bom, err := br.Peek(3); err == nil && string(bom) == "\xEF\xBB\xBF" {
+		br.Discard(3)
+	}
+	reader := csv.NewReader(br)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	return reader
+}
+
+// parseExcuseCSV reads a CSV with a header row naming the date, goal and excuse columns, in English or Japanese.
+func parseExcuseCSV(r io.Reader) ([]importRow, error) {
+	reader := newImportCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	dateCol, goalCol, excuseCol := -1, -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "date", "日付":
+			dateCol = i
+		case "goal", "goal title", "目標":
+			goalCol = i
+		case "excuse", "excuse text", "言い訳":
+			excuseCol = i
+		}
+	}
+	if dateCol < 0 || goalCol < 0 || excuseCol < 0 {
+		return nil, errImportHeader
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, importRow{
+			Line:       line,
+			Date:       strings.TrimSpace(field(record, dateCol)),
+			GoalTitle:  strings.TrimSpace(field(record, goalCol)),
+			ExcuseText: strings.TrimSpace(field(record, excuseCol)),
+		})
+	}
+	return rows, nil
+}
+
+// parseLoopCheckmarksCSV reads Checkmarks.csv of a Loop Habit Tracker export: a Date column followed by
+// one column per habit. Days marked as not done (0) become excuses.
+func parseLoopCheckmarksCSV(r io.Reader) ([]importRow, error) {
+	reader := newImportCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+	if len(header) < 2 || !strings.EqualFold(strings.TrimSpace(header[0]), "date") {
+		return nil, errImportHeader
+	}
+
+	var rows []importRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		date := strings.TrimSpace(field(record, 0))
+		for i := 1; i < len(header); i++ {
+			habit := strings.TrimSpace(header[i])
+			if habit == "" || strings.TrimSpace(field(record, i)) != "0" {
+				continue
+			}
+			rows = append(rows, importRow{Line: line, Date: date, GoalTitle: habit, ExcuseText: loopMissedExcuse})
+		}
+	}
+	return rows, nil
+}
+
+func field(record []string, i int) string {
+	if i < len(record) {
+		return record[i]
+	}
+	return ""
+}
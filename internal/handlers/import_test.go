@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExcuseCSV(t *testing.T) {
+	t.Run("JapaneseHeaderWithBOM", func(t *testing.T) {
+		input := "\xEF\xBB\xBF日付,目標,言い訳\n2025-01-01,本を読む,\"眠かった,とても\"\n2025-01-02,走る,雨\n"
+		rows, err := parseExcuseCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		assert.Equal(t, []importRow{
+			{Line: 2, Date: "2025-01-01", GoalTitle: "本を読む", ExcuseText: "眠かった,とても"},
+			{Line: 3, Date: "2025-01-02", GoalTitle: "走る", ExcuseText: "雨"},
+		}, rows)
+	})
+
+	t.Run("ExportFormat", func(t *testing.T) {
+		input := "date,goal,excuse,template,created_at\n2025-01-01,本を読む,眠かった,,2025-01-01T12:00:00Z\n"
+		rows, err := parseExcuseCSV(strings.NewReader(input))
+		require.NoError(t, err)
+		assert.Len(t, rows, 1)
+	})
+
+	t.Run("MissingColumn", func(t *testing.T) {
+		_, err := parseExcuseCSV(strings.NewReader("date,excuse\n2025-01-01,眠かった\n"))
+		assert.ErrorIs(t, err, errImportHeader)
+	})
+}
+
+func TestParseLoopCheckmarksCSV(t *testing.T) {
+	input := "Date,Meditate,Run,\n2025-01-02,2,0,\n2025-01-01,0,-1,\n"
+	rows, err := parseLoopCheckmarksCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, []importRow{
+		{Line: 2, Date: "2025-01-02", GoalTitle: "Run", ExcuseText: loopMissedExcuse},
+		{Line: 3, Date: "2025-01-01", GoalTitle: "Meditate", ExcuseText: loopMissedExcuse},
+	}, rows)
+}
+
+func TestPostMeImports(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := "auth0|test"
+	loc := services.LoadLocation(services.DefaultTimeZone)
+	yesterday := time.Now().In(loc).AddDate(0, 0, -1).Format("2006-01-02")
+	tomorrow := time.Now().In(loc).AddDate(0, 0, 1).Format("2006-01-02")
+	input := "date,goal,excuse\n" +
+		yesterday + ",本を読む,眠かった\n" +
+		tomorrow + ",本を読む,未来\n" +
+		"2025-13-01,走る,雨\n" +
+		yesterday + ",走る,雨\n"
+
+	postFile := func(handler *ImportHandler, query string, file []byte, entitlements services.Entitlements) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, _ := mw.CreateFormFile("file", "excuses.csv")
+		fw.Write(file)
+		mw.Close()
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", entitlements)
+		c.Request, _ = http.NewRequest("POST", "/me/imports"+query, &body)
+		c.Request.Header.Set("Content-Type", mw.FormDataContentType())
+		handler.PostMeImports(c)
+		return w
+	}
+	post := func(handler *ImportHandler, query string, entitlements services.Entitlements) *httptest.ResponseRecorder {
+		return postFile(handler, query, []byte(input), entitlements)
+	}
+
+	t.Run("DryRun", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewImportHandler(db, services.NewRuleModerator(nil))
+
+		w := post(handler, "?dryRun=true", services.Entitlements{MaxGoals: 3})
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp ImportResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, 2, resp.GoalsCreated)
+		assert.Equal(t, 2, resp.ExcusesCreated)
+		assert.Equal(t, []int{3, 4}, []int{resp.Errors[0].Line, resp.Errors[1].Line})
+
+		var count int64
+		db.Model(&models.ExcuseEntry{}).Count(&count)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("Import", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewImportHandler(db, services.NewRuleModerator(nil))
+
+		w := post(handler, "", services.Entitlements{MaxGoals: 1})
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp ImportResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, 1, resp.GoalsCreated)
+		assert.Equal(t, 1, resp.ExcusesCreated)
+		// Future date, invalid date and the goal over the plan limit
+		assert.Len(t, resp.Errors, 3)
+
+		var goal models.Goal
+		db.First(&goal, "user_id = ? AND title = ?", userID, "本を読む")
+		assert.Equal(t, yesterday, goal.CreatedAt.In(loc).Format("2006-01-02"))
+
+		// Importing again updates the same day
+		w = post(handler, "", services.Entitlements{MaxGoals: 1})
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, 0, resp.ExcusesCreated)
+		assert.Equal(t, 1, resp.ExcusesUpdated)
+	})
+	t.Run("TooLarge", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewImportHandler(db, services.NewRuleModerator(nil))
+
+		w := postFile(handler, "", bytes.Repeat([]byte("x"), maxImportFileSize+multipartOverhead), services.Entitlements{MaxGoals: 3})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "ファイルサイズが大きすぎます")
+	})
+
+	t.Run("ExportedArchive", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewImportHandler(db, services.NewRuleModerator(nil))
+
+		exporterID := "auth0|exporter"
+		goal := models.Goal{UserID: exporterID, Title: "本を読む"}
+		db.Create(&goal)
+		db.Create(&models.ExcuseEntry{UserID: exporterID, GoalID: goal.ID, Date: models.Date(yesterday), ExcuseText: "眠かった, とても"})
+		archive, err := services.NewExportService(db).BuildArchive(exporterID)
+		require.NoError(t, err)
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		require.NoError(t, err)
+		rc, err := zr.Open("excuses.csv")
+		require.NoError(t, err)
+		exported, _ := io.ReadAll(rc)
+		rc.Close()
+
+		w := postFile(handler, "", exported, services.Entitlements{MaxGoals: 3})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp ImportResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, 1, resp.GoalsCreated)
+		assert.Equal(t, 1, resp.ExcusesCreated)
+		var entry models.ExcuseEntry
+		require.NoError(t, db.First(&entry, "user_id = ?", userID).Error)
+		assert.Equal(t, models.Date(yesterday), entry.Date)
+		assert.Equal(t, "眠かった, とても", entry.ExcuseText)
+	})
+}
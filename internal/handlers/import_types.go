@@ -0,0 +1,26 @@
+package handlers
+
+type ImportRowError struct {
+	Line  int    `json:"line" example:"3"` // Line in the file, the header being line 1
+	Error string `json:"error" example:"未来の日付には保存できません"`
+}
+
+type ImportResponse struct {
+	DryRun         bool             `json:"dryRun" example:"false"`
+	GoalsCreated   int              `json:"goalsCreated" example:"2"`
+	ExcusesCreated int              `json:"excusesCreated" example:"120"`
+	ExcusesUpdated int              `json:"excusesUpdated" example:"3"`
+	Errors         []ImportRowError `json:"errors"`
+}
+
+type ImportValidationErrorResponse struct {
+	Error string `json:"error" example:"必要な列がありません"`
+}
+
+type ImportUnauthorizedResponse struct {
+	Error string `json:"error" example:"認証されていません"`
+}
+
+type ImportErrorResponse struct {
+	Error string `json:"error" example:"取り込みに失敗しました"`
+}
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"time"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// userLocation returns the time zone set on the context by the user middleware.
+func userLocation(c *gin.Context) *time.Location {
+	if loc, exists := c.Get("location"); exists {
+		return loc.(*time.Location)
+	}
+	return services.LoadLocation(services.DefaultTimeZone)
+}
+
+// userToday returns today's date (YYYY-MM-DD) in the user's time zone.
+func userToday(c *gin.Context) string {
+	return time.Now().In(userLocation(c)).Format("2006-01-02")
+}
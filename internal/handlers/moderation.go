@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// enqueueModerationReview puts text flagged by the moderator into the review queue.
+func enqueueModerationReview(db *gorm.DB, userID string, source string, excuseEntryID *uuid.UUID, result *services.ModerationResult) error {
+	return db.Create(&models.ModerationReview{
+		UserID:        userID,
+		Source:        source,
+		ExcuseEntryID: excuseEntryID,
+		Text:          result.Text,
+		Reasons:       result.Reasons,
+		Status:        "pending",
+	}).Error
+}
@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"what-went-wrong-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PauseHandler struct {
+	db *gorm.DB
+}
+
+func NewPauseHandler(db *gorm.DB) *PauseHandler {
+	return &PauseHandler{db: db}
+}
+
+// GetGoalPauses godoc
+// @Summary List goal pauses
+// @Description List the pause periods of the goal, earliest first. Vacations are listed by GET /me/vacations.
+// @Tags pauses
+// @Produce json
+// @Param id path string true "Goal ID" format:uuid
+// @Success 200 {object} GetPausePeriodsResponse
+// @Failure 400 {object} GoalValidationErrorResponse
+// @Failure 401 {object} PauseUnauthorizedResponse
+// @Failure 404 {object} GoalNotFoundErrorResponse
+// @Failure 500 {object} PauseFetchErrorResponse
+// @Security BearerAuth
+// @Router /goals/{id}/pauses [get]
+func (h *PauseHandler) GetGoalPauses(c *gin.Context) {
+	goal, ok := h.findGoal(c)
+	if !ok {
+		return
+	}
+	h.listPauses(c, h.db.Where("user_id = ? AND goal_id = ?", goal.UserID, goal.ID))
+}
+
+// PostGoalPauses godoc
+// @Summary Pause goal
+// @Description Freeze the goal from startDate to endDate (both included): no reminders, no excuses, and the days do not count in the goal's summary. startDate must not be before the user's today.
+// @Tags pauses
+// @Accept json
+// @Produce json
+// @Param id path string true "Goal ID" format:uuid
+// @Param request body CreatePausePeriodRequest true "Request body"
+// @Success 201 {object} PausePeriodResponse
+// @Failure 400 {object} PauseValidationErrorResponse
+// @Failure 401 {object} PauseUnauthorizedResponse
+// @Failure 404 {object} GoalNotFoundErrorResponse
+// @Failure 500 {object} PauseSaveErrorResponse
+// @Security BearerAuth
+// @Router /goals/{id}/pauses [post]
+func (h *PauseHandler) PostGoalPauses(c *gin.Context) {
+	goal, ok := h.findGoal(c)
+	if !ok {
+		return
+	}
+	h.createPause(c, goal.UserID, &goal.ID)
+}
+
+// DeleteGoalPause godoc
+// @Summary Cancel goal pause
+// @Description Remove a pause that has not started yet. A pause that is running ends yesterday instead, so the days already paused stay paused. A pause that is over cannot be cancelled.
+// @Tags pauses
+// @Produce json
+// @Param id path string true "Goal ID" format:uuid
+// @Param pauseId path string true "Pause ID" format:uuid
+// @Success 204
+// @Failure 400 {object} GoalValidationErrorResponse
+// @Failure 401 {object} PauseUnauthorizedResponse
+// @Failure 404 {object} PauseNotFoundResponse
+// @Failure 409 {object} PauseEndedResponse
+// @Failure 500 {object} PauseSaveErrorResponse
+// @Security BearerAuth
+// @Router /goals/{id}/pauses/{pauseId} [delete]
+func (h *PauseHandler) DeleteGoalPause(c *gin.Context) {
+	goal, ok := h.findGoal(c)
+	if !ok {
+		return
+	}
+	h.cancelPause(c, c.Param("pauseId"), h.db.Where("user_id = ? AND goal_id = ?", goal.UserID, goal.ID))
+}
+
+// GetMeVacations godoc
+// @Summary List vacations
+// @Description List the vacations of the current user, earliest first. A vacation pauses every goal.
+// @Tags pauses
+// @Produce json
+// @Success 200 {object} GetPausePeriodsResponse
+// @Failure 401 {object} PauseUnauthorizedResponse
+// @Failure 500 {object} PauseFetchErrorResponse
+// @Security BearerAuth
+// @Router /me/vacations [get]
+func (h *PauseHandler) GetMeVacations(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	h.listPauses(c, h.db.Where("user_id = ? AND goal_id IS NULL", userIDStr.(string)))
+}
+
+// PostMeVacations godoc
+// @Summary Start vacation
+// @Description Pause every goal of the current user from startDate to endDate (both included), including goals created during the vacation. startDate must not be before the user's today.
+// @Tags pauses
+// @Accept json
+// @Produce json
+// @Param request body CreatePausePeriodRequest true "Request body"
+// @Success 201 {object} PausePeriodResponse
+// @Failure 400 {object} PauseValidationErrorResponse
+// @Failure 401 {object} PauseUnauthorizedResponse
+// @Failure 500 {object} PauseSaveErrorResponse
+// @Security BearerAuth
+// @Router /me/vacations [post]
+func (h *PauseHandler) PostMeVacations(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	h.createPause(c, userIDStr.(string), nil)
+}
+
+// DeleteMeVacation godoc
+// @Summary Cancel vacation
+// @Description Remove a vacation that has not started yet. A vacation that is running ends yesterday instead. A vacation that is over cannot be cancelled.
+// @Tags pauses
+// @Produce json
+// @Param id path string true "Vacation ID" format:uuid
+// @Success 204
+// @Failure 400 {object} GoalValidationErrorResponse
+// @Failure 401 {object} PauseUnauthorizedResponse
+// @Failure 404 {object} PauseNotFoundResponse
+// @Failure 409 {object} PauseEndedResponse
+// @Failure 500 {object} PauseSaveErrorResponse
+// @Security BearerAuth
+// @Router /me/vacations/{id} [delete]
+func (h *PauseHandler) DeleteMeVacation(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	h.cancelPause(c, c.Param("id"), h.db.Where("user_id = ? AND goal_id IS NULL", userIDStr.(string)))
+}
+
+func (h *PauseHandler) findGoal(c *gin.Context) (models.Goal, bool) {
+	var goal models.Goal
+	goalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return goal, false
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return goal, false
+	}
+
+	if err := h.db.First(&goal, "id = ? AND user_id = ?", goalID, userIDStr.(string)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "目標が見つかりません"})
+			return goal, false
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の取得に失敗しました"})
+		return goal, false
+	}
+	return goal, true
+}
+
+func (h *PauseHandler) listPauses(c *gin.Context, query *gorm.DB) {
+	var pauses []models.PausePeriod
+	if err := query.Order("start_date asc").Find(&pauses).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "休止期間の取得に失敗しました"})
+		return
+	}
+
+	res := GetPausePeriodsResponse{Pauses: make([]PausePeriodResponse, len(pauses))}
+	for i, p := range pauses {
+		res.Pauses[i] = mapToPausePeriodResponse(p)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *PauseHandler) createPause(c *gin.Context, userID string, goalID *uuid.UUID) {
+	var req CreatePausePeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": bindErrorMessage(err)})
+		return
+	}
+	// Pausing past days would rewrite the goal's record
+	if string(req.StartDate) < userToday(c) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "過去の日付から休止することはできません"})
+		return
+	}
+	if req.EndDate < req.StartDate {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "終了日は開始日以降の日付にしてください"})
+		return
+	}
+
+	pause := models.PausePeriod{
+		UserID:    userID,
+		GoalID:    goalID,
+		StartDate: models.Date(req.StartDate),
+		EndDate:   models.Date(req.EndDate),
+	}
+	if err := h.db.Create(&pause).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "休止期間の保存に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapToPausePeriodResponse(pause))
+}
+
+// cancelPause deletes the pause matched by id within scope, or ends it yesterday when it has already started.
+func (h *PauseHandler) cancelPause(c *gin.Context, id string, scope *gorm.DB) {
+	pauseID, err := uuid.Parse(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+
+	var pause models.PausePeriod
+	if err := scope.First(&pause, "id = ?", pauseID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "休止期間が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "休止期間の取得に失敗しました"})
+		return
+	}
+
+	today := userToday(c)
+	switch {
+	case string(pause.StartDate) >= today:
+		err = h.db.Delete(&pause).Error
+	case string(pause.EndDate) >= today:
+		t, _ := time.Parse(dateLayout, today)
+		err = h.db.Model(&pause).Update("end_date", t.AddDate(0, 0, -1).Format(dateLayout)).Error
+	default:
+		c.JSON(http.StatusConflict, gin.H{"error": "終了した休止期間は取り消せません"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "休止期間の保存に失敗しました"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// isPaused reports whether the goal is paused on date, by a pause of its own or a vacation of its user.
+func isPaused(db *gorm.DB, goal models.Goal, date string) (bool, error) {
+	var count int64
+	err := db.Model(&models.PausePeriod{}).
+		Where("user_id = ? AND (goal_id = ? OR goal_id IS NULL)", goal.UserID, goal.ID).
+		Where("start_date <= ? AND end_date >= ?", date, date).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func mapToPausePeriodResponse(p models.PausePeriod) PausePeriodResponse {
+	res := PausePeriodResponse{
+		ID:        p.ID.String(),
+		StartDate: string(p.StartDate),
+		EndDate:   string(p.EndDate),
+		CreatedAt: p.CreatedAt,
+	}
+	if p.GoalID != nil {
+		goalID := p.GoalID.String()
+		res.GoalID = &goalID
+	}
+	return res
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoalPauses(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewPauseHandler(db)
+
+	userID := "auth0|test"
+	goal := models.Goal{UserID: userID, Title: "Run"}
+	db.Create(&goal)
+
+	now := time.Now().In(services.LoadLocation(services.DefaultTimeZone))
+	date := func(days int) string { return now.AddDate(0, 0, days).Format("2006-01-02") }
+
+	call := func(handle gin.HandlerFunc, method string, pauseID string, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Params = gin.Params{{Key: "id", Value: goal.ID.String()}, {Key: "pauseId", Value: pauseID}}
+		c.Request, _ = http.NewRequest(method, "/goals/"+goal.ID.String()+"/pauses", strings.NewReader(body))
+		handle(c)
+		// c.Status alone does not reach the recorder
+		c.Writer.WriteHeaderNow()
+		return w
+	}
+
+	t.Run("Validation", func(t *testing.T) {
+		w := call(handler.PostGoalPauses, "POST", "", `{"startDate": "`+date(-1)+`", "endDate": "`+date(3)+`"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = call(handler.PostGoalPauses, "POST", "", `{"startDate": "`+date(3)+`", "endDate": "`+date(1)+`"}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("CreateAndList", func(t *testing.T) {
+		w := call(handler.PostGoalPauses, "POST", "", `{"startDate": "`+date(0)+`", "endDate": "`+date(3)+`"}`)
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var pause PausePeriodResponse
+		json.Unmarshal(w.Body.Bytes(), &pause)
+		assert.Equal(t, goal.ID.String(), *pause.GoalID)
+
+		w = call(handler.GetGoalPauses, "GET", "", "")
+		var list GetPausePeriodsResponse
+		json.Unmarshal(w.Body.Bytes(), &list)
+		assert.Len(t, list.Pauses, 1)
+	})
+
+	t.Run("CancelNotStarted", func(t *testing.T) {
+		pause := models.PausePeriod{UserID: userID, GoalID: &goal.ID, StartDate: models.Date(date(5)), EndDate: models.Date(date(7))}
+		db.Create(&pause)
+
+		w := call(handler.DeleteGoalPause, "DELETE", pause.ID.String(), "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		var count int64
+		db.Model(&models.PausePeriod{}).Where("id = ?", pause.ID).Count(&count)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("CancelRunningEndsYesterday", func(t *testing.T) {
+		pause := models.PausePeriod{UserID: userID, GoalID: &goal.ID, StartDate: models.Date(date(-3)), EndDate: models.Date(date(2))}
+		db.Create(&pause)
+
+		w := call(handler.DeleteGoalPause, "DELETE", pause.ID.String(), "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		db.First(&pause, "id = ?", pause.ID)
+		assert.Equal(t, models.Date(date(-1)), pause.EndDate)
+	})
+
+	t.Run("CancelOnStartDay", func(t *testing.T) {
+		pause := models.PausePeriod{UserID: userID, GoalID: &goal.ID, StartDate: models.Date(date(0)), EndDate: models.Date(date(2))}
+		db.Create(&pause)
+
+		w := call(handler.DeleteGoalPause, "DELETE", pause.ID.String(), "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		var count int64
+		db.Model(&models.PausePeriod{}).Where("id = ?", pause.ID).Count(&count)
+		assert.Equal(t, int64(0), count)
+	})
+
+	t.Run("CancelOnEndDay", func(t *testing.T) {
+		pause := models.PausePeriod{UserID: userID, GoalID: &goal.ID, StartDate: models.Date(date(-2)), EndDate: models.Date(date(0))}
+		db.Create(&pause)
+
+		w := call(handler.DeleteGoalPause, "DELETE", pause.ID.String(), "")
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		db.First(&pause, "id = ?", pause.ID)
+		assert.Equal(t, models.Date(date(-1)), pause.EndDate)
+	})
+
+	t.Run("CancelEnded", func(t *testing.T) {
+		pause := models.PausePeriod{UserID: userID, GoalID: &goal.ID, StartDate: models.Date(date(-10)), EndDate: models.Date(date(-8))}
+		db.Create(&pause)
+
+		w := call(handler.DeleteGoalPause, "DELETE", pause.ID.String(), "")
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestMeVacations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewPauseHandler(db)
+
+	userID := "auth0|test"
+	goal := models.Goal{UserID: userID, Title: "Run"}
+	db.Create(&goal)
+	today := testToday()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", userID)
+	c.Request, _ = http.NewRequest("POST", "/me/vacations", strings.NewReader(`{"startDate": "`+today+`", "endDate": "`+today+`"}`))
+	handler.PostMeVacations(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var vacation PausePeriodResponse
+	json.Unmarshal(w.Body.Bytes(), &vacation)
+	assert.Nil(t, vacation.GoalID)
+
+	// The vacation pauses every goal
+	paused, err := isPaused(db, goal, today)
+	assert.NoError(t, err)
+	assert.True(t, paused)
+
+	// Goal pauses are not listed as vacations
+	db.Create(&models.PausePeriod{UserID: userID, GoalID: &goal.ID, StartDate: models.Date(today), EndDate: models.Date(today)})
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Set("userID", userID)
+	c.Request, _ = http.NewRequest("GET", "/me/vacations", nil)
+	handler.GetMeVacations(c)
+
+	var list GetPausePeriodsResponse
+	json.Unmarshal(w.Body.Bytes(), &list)
+	assert.Len(t, list.Pauses, 1)
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import "time"
+
+type CreatePausePeriodRequest struct {
+	StartDate Date `json:"startDate" binding:"required" swaggertype:"string" format:"date" example:"2023-12-28"`
+	EndDate   Date `json:"endDate" binding:"required" swaggertype:"string" format:"date" example:"2024-01-03"` // Included
+}
+
+type PausePeriodResponse struct {
+	ID        string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	GoalID    *string   `json:"goalId,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"` // Absent for a vacation
+	StartDate string    `json:"startDate" format:"date" example:"2023-12-28"`
+	EndDate   string    `json:"endDate" format:"date" example:"2024-01-03"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type GetPausePeriodsResponse struct {
+	Pauses []PausePeriodResponse `json:"pauses"`
+}
+
+type PauseValidationErrorResponse struct {
+	Error string `json:"error" example:"終了日は開始日以降の日付にしてください"`
+}
+
+type PauseNotFoundResponse struct {
+	Error string `json:"error" example:"休止期間が見つかりません"`
+}
+
+type PauseEndedResponse struct {
+	Error string `json:"error" example:"終了した休止期間は取り消せません"`
+}
+
+type PauseUnauthorizedResponse struct {
+	Error string `json:"error" example:"認証されていません"`
+}
+
+type PauseFetchErrorResponse struct {
+	Error string `json:"error" example:"休止期間の取得に失敗しました"`
+}
+
+type PauseSaveErrorResponse struct {
+	Error string `json:"error" example:"休止期間の保存に失敗しました"`
+}
@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SuggestedGoalHandler struct {
+	db *gorm.DB
+}
+
+func NewSuggestedGoalHandler(db *gorm.DB) *SuggestedGoalHandler {
+	return &SuggestedGoalHandler{db: db}
+}
+
+// GetSuggestedGoals godoc
+// @Summary List suggested goals
+// @Description List the curated goal suggestions shown on the goal creation screen. Titles follow Accept-Language, or the user's locale when it is not sent.
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Param Accept-Language header string false "Language of titles (ja, en)"
+// @Param category query string false "Filter by category" Enums(health, study, lifestyle, mind, work)
+// @Success 200 {object} GetSuggestedGoalsResponse
+// @Failure 500 {object} SuggestedGoalFetchErrorResponse
+// @Security BearerAuth
+// @Router /suggested-goals [get]
+func (h *SuggestedGoalHandler) GetSuggestedGoals(c *gin.Context) {
+	query := h.db.Where("is_active = ?", true)
+	if category := c.Query("category"); category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	var suggestions []models.SuggestedGoal
+	if err := query.Order("\"order\" asc").Find(&suggestions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "おすすめ目標の取得に失敗しました"})
+		return
+	}
+
+	english := userLocale(c) == "en"
+
+	res := GetSuggestedGoalsResponse{SuggestedGoals: make([]SuggestedGoalResponse, len(suggestions))}
+	for i, s := range suggestions {
+		res.SuggestedGoals[i] = SuggestedGoalResponse{
+			ID:                      s.ID,
+			Title:                   suggestedGoalTitle(s, english),
+			Category:                s.Category,
+			Icon:                    s.Icon,
+			DefaultNotificationTime: s.DefaultNotificationTime,
+			RecommendedSchedule:     s.RecommendedSchedule,
+		}
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// PostGoalFromSuggestion godoc
+// @Summary Create goal from suggestion
+// @Description Create a goal prefilled from a suggestion: the localized title, its icon and, when set, its default notification time. Goes through the same plan limit as POST /goals.
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Param Accept-Language header string false "Language of the goal title (ja, en)"
+// @Param id path string true "Suggested goal ID"
+// @Success 201 {object} CreateGoalResponse
+// @Failure 401 {object} GoalUnauthorizedResponse
+// @Failure 403 {object} GoalLimitReachedResponse
+// @Failure 404 {object} SuggestedGoalNotFoundResponse
+// @Failure 500 {object} GoalCreateErrorResponse
+// @Security BearerAuth
+// @Router /suggested-goals/{id}/goals [post]
+func (h *SuggestedGoalHandler) PostGoalFromSuggestion(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	entitlementsInterface, exists := c.Get("entitlements")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "プラン情報の取得に失敗しました"})
+		return
+	}
+	entitlements := entitlementsInterface.(services.Entitlements)
+
+	var suggestion models.SuggestedGoal
+	if err := h.db.Where("id = ? AND is_active = ?", c.Param("id"), true).First(&suggestion).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "おすすめ目標が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "おすすめ目標の取得に失敗しました"})
+		return
+	}
+
+	newGoal := models.Goal{
+		UserID:              userID,
+		Title:               suggestedGoalTitle(suggestion, userLocale(c) == "en"),
+		NotificationTime:    suggestion.DefaultNotificationTime,
+		NotificationEnabled: suggestion.DefaultNotificationTime != nil,
+		Icon:                suggestion.Icon,
+	}
+
+	if err := createGoal(h.db, &newGoal, entitlements); err != nil {
+		if errors.Is(err, errGoalLimitReached) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "プランの目標作成数上限に達しました"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "目標の作成に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateGoalResponse{
+		Goal: mapToGoalResponse(newGoal),
+	})
+}
+
+// GetAdminSuggestedGoals godoc
+// @Summary List suggested goals (admin)
+// @Description List every suggestion including inactive ones, with both titles. Requires the admin:catalog permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} GetAdminSuggestedGoalsResponse
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} SuggestedGoalFetchErrorResponse
+// @Security BearerAuth
+// @Router /admin/suggested-goals [get]
+func (h *SuggestedGoalHandler) GetAdminSuggestedGoals(c *gin.Context) {
+	var suggestions []models.SuggestedGoal
+	if err := h.db.Order("\"order\" asc").Find(&suggestions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "おすすめ目標の取得に失敗しました"})
+		return
+	}
+
+	res := GetAdminSuggestedGoalsResponse{SuggestedGoals: make([]AdminSuggestedGoalResponse, len(suggestions))}
+	for i, s := range suggestions {
+		res.SuggestedGoals[i] = mapToAdminSuggestedGoalResponse(s)
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// PostAdminSuggestedGoal godoc
+// @Summary Create suggested goal (admin)
+// @Description Add an entry to the catalogue. Requires the admin:catalog permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body CreateSuggestedGoalRequest true "Request body"
+// @Success 201 {object} AdminSuggestedGoalResponse
+// @Failure 400 {object} SuggestedGoalValidationErrorResponse
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} SuggestedGoalConflictResponse
+// @Failure 500 {object} SuggestedGoalSaveErrorResponse
+// @Security BearerAuth
+// @Router /admin/suggested-goals [post]
+func (h *SuggestedGoalHandler) PostAdminSuggestedGoal(c *gin.Context) {
+	var req CreateSuggestedGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+
+	var count int64
+	if err := h.db.Model(&models.SuggestedGoal{}).Where("id = ?", req.ID).Count(&count).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "おすすめ目標の保存に失敗しました"})
+		return
+	}
+	if count > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "同じIDのおすすめ目標が既にあります"})
+		return
+	}
+
+	suggestion := models.SuggestedGoal{
+		ID:                      req.ID,
+		TitleJa:                 req.TitleJa,
+		TitleEn:                 req.TitleEn,
+		Category:                req.Category,
+		Icon:                    nilIfEmpty(req.Icon),
+		DefaultNotificationTime: nilIfEmpty(req.DefaultNotificationTime),
+		RecommendedSchedule:     req.RecommendedSchedule,
+		IsActive:                req.IsActive,
+		Order:                   req.Order,
+	}
+	// Select("*") so that IsActive=false is written instead of the column default
+	if err := h.db.Select("*").Create(&suggestion).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "おすすめ目標の保存に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapToAdminSuggestedGoalResponse(suggestion))
+}
+
+// PatchAdminSuggestedGoal godoc
+// @Summary Update suggested goal (admin)
+// @Description Update an entry of the catalogue. Set isActive to false to hide it without deleting it. Requires the admin:catalog permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Suggested goal ID"
+// @Param request body UpdateSuggestedGoalRequest true "Request body"
+// @Success 200 {object} AdminSuggestedGoalResponse
+// @Failure 400 {object} SuggestedGoalValidationErrorResponse
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} SuggestedGoalNotFoundResponse
+// @Failure 500 {object} SuggestedGoalSaveErrorResponse
+// @Security BearerAuth
+// @Router /admin/suggested-goals/{id} [patch]
+func (h *SuggestedGoalHandler) PatchAdminSuggestedGoal(c *gin.Context) {
+	var req UpdateSuggestedGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+
+	var suggestion models.SuggestedGoal
+	if err := h.db.Where("id = ?", c.Param("id")).First(&suggestion).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "おすすめ目標が見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "おすすめ目標の取得に失敗しました"})
+		return
+	}
+
+	if req.TitleJa != nil {
+		suggestion.TitleJa = *req.TitleJa
+	}
+	if req.TitleEn != nil {
+		suggestion.TitleEn = *req.TitleEn
+	}
+	if req.Category != nil {
+		suggestion.Category = *req.Category
+	}
+	if req.Icon != nil {
+		suggestion.Icon = nilIfEmpty(req.Icon)
+	}
+	if req.DefaultNotificationTime != nil {
+		suggestion.DefaultNotificationTime = nilIfEmpty(req.DefaultNotificationTime)
+	}
+	if req.RecommendedSchedule != nil {
+		suggestion.RecommendedSchedule = *req.RecommendedSchedule
+	}
+	if req.IsActive != nil {
+		suggestion.IsActive = *req.IsActive
+	}
+	if req.Order != nil {
+		suggestion.Order = *req.Order
+	}
+
+	if err := h.db.Save(&suggestion).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "おすすめ目標の保存に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapToAdminSuggestedGoalResponse(suggestion))
+}
+
+// DeleteAdminSuggestedGoal godoc
+// @Summary Delete suggested goal (admin)
+// @Description Remove an entry from the catalogue. Goals already created from it are not affected. Requires the admin:catalog permission.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Suggested goal ID"
+// @Success 204
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} SuggestedGoalNotFoundResponse
+// @Failure 500 {object} SuggestedGoalSaveErrorResponse
+// @Security BearerAuth
+// @Router /admin/suggested-goals/{id} [delete]
+func (h *SuggestedGoalHandler) DeleteAdminSuggestedGoal(c *gin.Context) {
+	result := h.db.Where("id = ?", c.Param("id")).Delete(&models.SuggestedGoal{})
+	if result.Error != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "おすすめ目標の削除に失敗しました"})
+		return
+	}
+	if result.RowsAffected == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "おすすめ目標が見つかりません"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func suggestedGoalTitle(s models.SuggestedGoal, english bool) string {
+	if english {
+		return s.TitleEn
+	}
+	return s.TitleJa
+}
+
+func mapToAdminSuggestedGoalResponse(s models.SuggestedGoal) AdminSuggestedGoalResponse {
+	return AdminSuggestedGoalResponse{
+		ID:                      s.ID,
+		TitleJa:                 s.TitleJa,
+		TitleEn:                 s.TitleEn,
+		Category:                s.Category,
+		Icon:                    s.Icon,
+		DefaultNotificationTime: s.DefaultNotificationTime,
+		RecommendedSchedule:     s.RecommendedSchedule,
+		IsActive:                s.IsActive,
+		Order:                   s.Order,
+		CreatedAt:               s.CreatedAt,
+		UpdatedAt:               s.UpdatedAt,
+	}
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSuggestedGoals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewSuggestedGoalHandler(db)
+
+	db.Create(&models.SuggestedGoal{ID: "walk", TitleJa: "歩く", TitleEn: "Walk", Category: "health", RecommendedSchedule: "daily", IsActive: true, Order: 2})
+	db.Create(&models.SuggestedGoal{ID: "read", TitleJa: "読む", TitleEn: "Read", Category: "study", RecommendedSchedule: "weekdays", IsActive: true, Order: 1})
+	retired := models.SuggestedGoal{ID: "retired", TitleJa: "廃止", TitleEn: "Retired", Category: "study", RecommendedSchedule: "daily", Order: 3}
+	db.Create(&retired)
+	db.Model(&retired).Update("is_active", false)
+
+	t.Run("Japanese", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/suggested-goals", nil)
+
+		handler.GetSuggestedGoals(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp GetSuggestedGoalsResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Len(t, resp.SuggestedGoals, 2)
+		assert.Equal(t, "read", resp.SuggestedGoals[0].ID)
+		assert.Equal(t, "読む", resp.SuggestedGoals[0].Title)
+		assert.Equal(t, "weekdays", resp.SuggestedGoals[0].RecommendedSchedule)
+	})
+
+	t.Run("EnglishByCategory", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/suggested-goals?category=health", nil)
+		c.Request.Header.Set("Accept-Language", "en")
+
+		handler.GetSuggestedGoals(c)
+
+		var resp GetSuggestedGoalsResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Len(t, resp.SuggestedGoals, 1)
+		assert.Equal(t, "Walk", resp.SuggestedGoals[0].Title)
+	})
+}
+
+func TestPostGoalFromSuggestion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewSuggestedGoalHandler(db)
+
+	icon := "📚"
+	notificationTime := "21:00"
+	db.Create(&models.SuggestedGoal{ID: "read", TitleJa: "読む", TitleEn: "Read", Category: "study", Icon: &icon, DefaultNotificationTime: &notificationTime, RecommendedSchedule: "daily", IsActive: true, Order: 1})
+
+	t.Run("Success", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("POST", "/suggested-goals/read/goals", nil)
+		c.Params = gin.Params{{Key: "id", Value: "read"}}
+		c.Set("userID", "suggest-user")
+		c.Set("entitlements", services.Entitlements{MaxGoals: 1})
+
+		handler.PostGoalFromSuggestion(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var resp CreateGoalResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, "読む", resp.Goal.Title)
+		assert.Equal(t, "21:00", *resp.Goal.NotificationTime)
+		assert.True(t, resp.Goal.NotificationEnabled)
+		assert.Equal(t, "📚", *resp.Goal.Icon)
+	})
+
+	t.Run("LimitReached", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("POST", "/suggested-goals/read/goals", nil)
+		c.Params = gin.Params{{Key: "id", Value: "read"}}
+		c.Set("userID", "suggest-user")
+		c.Set("entitlements", services.Entitlements{MaxGoals: 1})
+
+		handler.PostGoalFromSuggestion(c)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("POST", "/suggested-goals/missing/goals", nil)
+		c.Params = gin.Params{{Key: "id", Value: "missing"}}
+		c.Set("userID", "suggest-user")
+		c.Set("entitlements", services.Entitlements{MaxGoals: 3})
+
+		handler.PostGoalFromSuggestion(c)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestAdminSuggestedGoals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewSuggestedGoalHandler(db)
+
+	t.Run("CreateInactive", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]any{
+			"id": "meditate", "titleJa": "瞑想する", "titleEn": "Meditate",
+			"category": "mind", "recommendedSchedule": "daily", "isActive": false, "order": 5,
+		})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("POST", "/admin/suggested-goals", bytes.NewBuffer(body))
+
+		handler.PostAdminSuggestedGoal(c)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var saved models.SuggestedGoal
+		db.First(&saved, "id = ?", "meditate")
+		assert.False(t, saved.IsActive)
+	})
+
+	t.Run("CreateDuplicate", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]any{
+			"id": "meditate", "titleJa": "瞑想する", "titleEn": "Meditate",
+			"category": "mind", "recommendedSchedule": "daily",
+		})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("POST", "/admin/suggested-goals", bytes.NewBuffer(body))
+
+		handler.PostAdminSuggestedGoal(c)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("InvalidCategory", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]any{
+			"id": "nap", "titleJa": "昼寝", "titleEn": "Nap",
+			"category": "sleep", "recommendedSchedule": "daily",
+		})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("POST", "/admin/suggested-goals", bytes.NewBuffer(body))
+
+		handler.PostAdminSuggestedGoal(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]any{"isActive": true, "defaultNotificationTime": "06:30"})
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("PATCH", "/admin/suggested-goals/meditate", bytes.NewBuffer(body))
+		c.Params = gin.Params{{Key: "id", Value: "meditate"}}
+
+		handler.PatchAdminSuggestedGoal(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp AdminSuggestedGoalResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.True(t, resp.IsActive)
+		assert.Equal(t, "06:30", *resp.DefaultNotificationTime)
+		assert.Equal(t, "瞑想する", resp.TitleJa)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("DELETE", "/admin/suggested-goals/meditate", nil)
+		c.Params = gin.Params{{Key: "id", Value: "meditate"}}
+
+		handler.DeleteAdminSuggestedGoal(c)
+		// c.Status alone does not reach the recorder
+		c.Writer.WriteHeaderNow()
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		var count int64
+		db.Model(&models.SuggestedGoal{}).Count(&count)
+		assert.Equal(t, int64(0), count)
+	})
+}
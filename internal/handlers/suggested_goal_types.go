@@ -0,0 +1,77 @@
+package handlers
+
+import "time"
+
+type SuggestedGoalResponse struct {
+	ID                      string  `json:"id" example:"read-10-pages"`
+	Title                   string  `json:"title" example:"本を10ページ読む"`
+	Category                string  `json:"category" example:"study"`
+	Icon                    *string `json:"icon,omitempty" example:"📚"`
+	DefaultNotificationTime *string `json:"defaultNotificationTime,omitempty" example:"21:00"`
+	RecommendedSchedule     string  `json:"recommendedSchedule" example:"daily"` // "daily", "weekdays", "weekends"
+}
+
+type GetSuggestedGoalsResponse struct {
+	SuggestedGoals []SuggestedGoalResponse `json:"suggestedGoals"`
+}
+
+type AdminSuggestedGoalResponse struct {
+	ID                      string    `json:"id" example:"read-10-pages"`
+	TitleJa                 string    `json:"titleJa" example:"本を10ページ読む"`
+	TitleEn                 string    `json:"titleEn" example:"Read 10 pages"`
+	Category                string    `json:"category" example:"study"`
+	Icon                    *string   `json:"icon,omitempty" example:"📚"`
+	DefaultNotificationTime *string   `json:"defaultNotificationTime,omitempty" example:"21:00"`
+	RecommendedSchedule     string    `json:"recommendedSchedule" example:"daily"`
+	IsActive                bool      `json:"isActive" example:"true"`
+	Order                   int       `json:"order" example:"1"`
+	CreatedAt               time.Time `json:"createdAt"`
+	UpdatedAt               time.Time `json:"updatedAt"`
+}
+
+type GetAdminSuggestedGoalsResponse struct {
+	SuggestedGoals []AdminSuggestedGoalResponse `json:"suggestedGoals"`
+}
+
+type CreateSuggestedGoalRequest struct {
+	ID                      string  `json:"id" binding:"required,max=100,lowercase" example:"read-10-pages"`
+	TitleJa                 string  `json:"titleJa" binding:"required,max=200" example:"本を10ページ読む"`
+	TitleEn                 string  `json:"titleEn" binding:"required,max=200" example:"Read 10 pages"`
+	Category                string  `json:"category" binding:"required,oneof=health study lifestyle mind work" example:"study"`
+	Icon                    *string `json:"icon" binding:"omitempty,max=16" example:"📚"`
+	DefaultNotificationTime *string `json:"defaultNotificationTime" binding:"omitempty,datetime=15:04" example:"21:00"`
+	RecommendedSchedule     string  `json:"recommendedSchedule" binding:"required,oneof=daily weekdays weekends" example:"daily"`
+	IsActive                bool    `json:"isActive" example:"true"`
+	Order                   int     `json:"order" example:"1"`
+}
+
+type UpdateSuggestedGoalRequest struct {
+	TitleJa                 *string `json:"titleJa" binding:"omitempty,max=200" example:"本を20ページ読む"`
+	TitleEn                 *string `json:"titleEn" binding:"omitempty,max=200" example:"Read 20 pages"`
+	Category                *string `json:"category" binding:"omitempty,oneof=health study lifestyle mind work" example:"study"`
+	Icon                    *string `json:"icon" binding:"omitempty,max=16" example:"📖"`                                // "" clears it
+	DefaultNotificationTime *string `json:"defaultNotificationTime" binding:"omitempty,datetime=15:04" example:"21:30"` // "" clears it
+	RecommendedSchedule     *string `json:"recommendedSchedule" binding:"omitempty,oneof=daily weekdays weekends" example:"weekdays"`
+	IsActive                *bool   `json:"isActive" example:"false"`
+	Order                   *int    `json:"order" example:"2"`
+}
+
+type SuggestedGoalValidationErrorResponse struct {
+	Error string `json:"error" example:"入力内容が正しくありません"`
+}
+
+type SuggestedGoalNotFoundResponse struct {
+	Error string `json:"error" example:"おすすめ目標が見つかりません"`
+}
+
+type SuggestedGoalConflictResponse struct {
+	Error string `json:"error" example:"同じIDのおすすめ目標が既にあります"`
+}
+
+type SuggestedGoalFetchErrorResponse struct {
+	Error string `json:"error" example:"おすすめ目標の取得に失敗しました"`
+}
+
+type SuggestedGoalSaveErrorResponse struct {
+	Error string `json:"error" example:"おすすめ目標の保存に失敗しました"`
+}
@@ -0,0 +1,451 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SyncHandler struct {
+	db        *gorm.DB
+	moderator services.Moderator
+}
+
+func NewSyncHandler(db *gorm.DB, moderator services.Moderator) *SyncHandler {
+	return &SyncHandler{db: db, moderator: moderator}
+}
+
+// GetSync godoc
+// @Summary Pull changes
+// @Description Return the goals and excuses changed, and the ones deleted, since the cursor of the previous pull. Omit the cursor for the first sync. Excuses outside the plan's retention window are not returned.
+// @Tags sync
+// @Produce json
+// @Param cursor query string false "Cursor returned by the previous pull"
+// @Success 200 {object} SyncPullResponse
+// @Failure 400 {object} SyncValidationErrorResponse
+// @Failure 401 {object} SyncUnauthorizedResponse
+// @Failure 500 {object} SyncErrorResponse
+// @Security BearerAuth
+// @Router /sync [get]
+func (h *SyncHandler) GetSync(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	entitlementsInterface, exists := c.Get("entitlements")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "プラン情報の取得に失敗しました"})
+		return
+	}
+	entitlements := entitlementsInterface.(services.Entitlements)
+
+	var cursor int64
+	if v := c.Query("cursor"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+			return
+		}
+		cursor = n
+	}
+
+	// Rows written before sync existed have sync_seq 0, so a first sync reads everything
+	var goals []models.Goal
+	if err := h.db.Where("user_id = ? AND sync_seq >= ?", userID, cursor+1).Order("sync_seq asc").Find(&goals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "同期に失敗しました"})
+		return
+	}
+
+	excuseQuery := h.db.Where("user_id = ? AND sync_seq >= ?", userID, cursor+1)
+	if entitlements.LogRetentionDays != nil {
+		excuseQuery = excuseQuery.Where("date >= ?", time.Now().In(userLocation(c)).AddDate(0, 0, -*entitlements.LogRetentionDays).Format(dateLayout))
+	}
+	var excuses []models.ExcuseEntry
+	if err := excuseQuery.Order("sync_seq asc").Find(&excuses).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "同期に失敗しました"})
+		return
+	}
+
+	// A first sync has nothing to delete
+	var tombstones []models.Tombstone
+	if cursor > 0 {
+		if err := h.db.Where("user_id = ? AND sync_seq > ?", userID, cursor).Order("sync_seq asc").Find(&tombstones).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "同期に失敗しました"})
+			return
+		}
+	}
+
+	next := cursor
+	res := SyncPullResponse{
+		Goals:     make([]GoalResponse, len(goals)),
+		Excuses:   make([]ExcuseResponse, len(excuses)),
+		Deletions: make([]SyncDeletion, len(tombstones)),
+	}
+	for i, g := range goals {
+		res.Goals[i] = mapToGoalResponse(g)
+		next = max(next, g.SyncSeq)
+	}
+	for i, e := range excuses {
+		res.Excuses[i] = mapToResponse(e)
+		next = max(next, e.SyncSeq)
+	}
+	for i, t := range tombstones {
+		res.Deletions[i] = SyncDeletion{Type: t.EntityType, ID: t.EntityID, DeletedAt: t.DeletedAt}
+		next = max(next, t.SyncSeq)
+	}
+	res.Cursor = strconv.FormatInt(next, 10)
+
+	c.JSON(http.StatusOK, res)
+}
+
+// PostSync godoc
+// @Summary Push changes
+// @Description Apply a batch of changes made offline, in order. IDs of new goals and excuses are generated by the client. With baseVersion the change is applied only if the row is still at that version (conflict otherwise, with the current row); without it the last write wins. Changes follow the same rules as the REST endpoints and are rejected with a message otherwise.
+// @Tags sync
+// @Accept json
+// @Produce json
+// @Param request body SyncPushRequest true "Request body"
+// @Success 200 {object} SyncPushResponse
+// @Failure 400 {object} SyncValidationErrorResponse
+// @Failure 401 {object} SyncUnauthorizedResponse
+// @Failure 500 {object} SyncErrorResponse
+// @Security BearerAuth
+// @Router /sync [post]
+func (h *SyncHandler) PostSync(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	entitlementsInterface, exists := c.Get("entitlements")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "プラン情報の取得に失敗しました"})
+		return
+	}
+	entitlements := entitlementsInterface.(services.Entitlements)
+
+	var req SyncPushRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": bindErrorMessage(err)})
+		return
+	}
+
+	res := SyncPushResponse{Results: make([]SyncMutationResult, len(req.Mutations))}
+	for i, m := range req.Mutations {
+		var result SyncMutationResult
+		err := h.db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			result, err = h.applyMutation(c, tx, userID, entitlements, m)
+			return err
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "同期に失敗しました"})
+			return
+		}
+		result.ClientMutationID = m.ClientMutationID
+		res.Results[i] = result
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *SyncHandler) applyMutation(c *gin.Context, tx *gorm.DB, userID string, entitlements services.Entitlements, m SyncMutation) (SyncMutationResult, error) {
+	id := uuid.MustParse(m.ID) // validated by binding
+	switch m.Type {
+	case "upsert_goal":
+		return h.upsertGoal(tx, userID, entitlements, id, m)
+	case "delete_goal":
+		return h.deleteGoal(tx, userID, id, m)
+	case "upsert_excuse":
+		return h.upsertExcuse(c, tx, userID, entitlements, id, m)
+	default:
+		return h.deleteExcuse(tx, userID, id, m)
+	}
+}
+
+func (h *SyncHandler) upsertGoal(tx *gorm.DB, userID string, entitlements services.Entitlements, id uuid.UUID, m SyncMutation) (SyncMutationResult, error) {
+	if m.Goal == nil {
+		return rejected("入力内容が正しくありません"), nil
+	}
+	if m.Goal.Color != nil && *m.Goal.Color != "" {
+		if ok, _, message := checkGoalColor(*m.Goal.Color, entitlements); !ok {
+			return rejected(message), nil
+		}
+	}
+	if !isValidGoalPeriod(dateOrNil(m.Goal.StartDate), dateOrNil(m.Goal.EndDate)) {
+		return rejected("終了日は開始日以降の日付にしてください"), nil
+	}
+
+	var goal models.Goal
+	err := tx.First(&goal, "id = ?", id).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return SyncMutationResult{}, err
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if deleted, err := isTombstoned(tx, userID, id); err != nil || deleted {
+			return SyncMutationResult{Status: "conflict"}, err
+		}
+
+		count, err := countActiveGoals(tx, userID)
+		if err != nil {
+			return SyncMutationResult{}, err
+		}
+		if int(count) >= entitlements.MaxGoals {
+			return rejected("プランの目標作成数上限に達しました"), nil
+		}
+		goal = models.Goal{
+			ID:                  id,
+			UserID:              userID,
+			Title:               m.Goal.Title,
+			NotificationTime:    m.Goal.NotificationTime,
+			NotificationEnabled: m.Goal.NotificationEnabled,
+			Icon:                nilIfEmpty(m.Goal.Icon),
+			Color:               nilIfEmpty(m.Goal.Color),
+			CoverImageURL:       nilIfEmpty(m.Goal.CoverImageURL),
+			StartDate:           dateOrNil(m.Goal.StartDate),
+			EndDate:             dateOrNil(m.Goal.EndDate),
+			MaxExcusesPerDay:    1,
+			Order:               int(count) + 1,
+		}
+		if m.Goal.MaxExcusesPerDay != nil {
+			goal.MaxExcusesPerDay = *m.Goal.MaxExcusesPerDay
+		}
+		if err := tx.Create(&goal).Error; err != nil {
+			return SyncMutationResult{}, err
+		}
+	} else {
+		if goal.UserID != userID {
+			return rejected("目標が見つかりません"), nil
+		}
+		if m.BaseVersion != nil && *m.BaseVersion != goal.Version {
+			res := mapToGoalResponse(goal)
+			return SyncMutationResult{Status: "conflict", Goal: &res}, nil
+		}
+
+		if m.Goal.MaxExcusesPerDay != nil && *m.Goal.MaxExcusesPerDay < goal.MaxExcusesPerDay {
+			err := checkDailyExcuseCap(tx, goal.UserID, goal.ID, *m.Goal.MaxExcusesPerDay)
+			if errors.Is(err, errDailyExcuseCapTooLow) {
+				return rejected(dailyExcuseCapTooLowMessage), nil
+			}
+			if err != nil {
+				return SyncMutationResult{}, err
+			}
+		}
+
+		goal.Title = m.Goal.Title
+		goal.NotificationTime = m.Goal.NotificationTime
+		goal.NotificationEnabled = m.Goal.NotificationEnabled
+		goal.Icon = nilIfEmpty(m.Goal.Icon)
+		goal.Color = nilIfEmpty(m.Goal.Color)
+		goal.CoverImageURL = nilIfEmpty(m.Goal.CoverImageURL)
+		goal.StartDate = dateOrNil(m.Goal.StartDate)
+		if !equalDatePtr(goal.EndDate, dateOrNil(m.Goal.EndDate)) {
+			if err := tx.Where("goal_id = ?", goal.ID).Delete(&models.GoalSummary{}).Error; err != nil {
+				return SyncMutationResult{}, err
+			}
+		}
+		goal.EndDate = dateOrNil(m.Goal.EndDate)
+		if m.Goal.MaxExcusesPerDay != nil {
+			goal.MaxExcusesPerDay = *m.Goal.MaxExcusesPerDay
+		}
+		goal.UpdatedAt = time.Now()
+		if err := tx.Save(&goal).Error; err != nil {
+			return SyncMutationResult{}, err
+		}
+	}
+
+	res := mapToGoalResponse(goal)
+	return SyncMutationResult{Status: "applied", Goal: &res}, nil
+}
+
+func (h *SyncHandler) deleteGoal(tx *gorm.DB, userID string, id uuid.UUID, m SyncMutation) (SyncMutationResult, error) {
+	var goal models.Goal
+	if err := tx.First(&goal, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Already deleted
+			return SyncMutationResult{Status: "applied"}, nil
+		}
+		return SyncMutationResult{}, err
+	}
+	if goal.UserID != userID {
+		return rejected("目標が見つかりません"), nil
+	}
+	if m.BaseVersion != nil && *m.BaseVersion != goal.Version {
+		res := mapToGoalResponse(goal)
+		return SyncMutationResult{Status: "conflict", Goal: &res}, nil
+	}
+
+	if err := deleteGoal(tx, goal); err != nil {
+		return SyncMutationResult{}, err
+	}
+	return SyncMutationResult{Status: "applied"}, nil
+}
+
+func (h *SyncHandler) upsertExcuse(c *gin.Context, tx *gorm.DB, userID string, entitlements services.Entitlements, id uuid.UUID, m SyncMutation) (SyncMutationResult, error) {
+	if m.Excuse == nil {
+		return rejected("入力内容が正しくありません"), nil
+	}
+
+	var excuse models.ExcuseEntry
+	err := tx.First(&excuse, "id = ?", id).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return SyncMutationResult{}, err
+	}
+	exists := err == nil
+
+	var goal models.Goal
+	if exists {
+		if excuse.UserID != userID {
+			return rejected("言い訳が見つかりません"), nil
+		}
+		if m.BaseVersion != nil && *m.BaseVersion != excuse.Version {
+			res := mapToResponse(excuse)
+			return SyncMutationResult{Status: "conflict", Excuse: &res}, nil
+		}
+	} else {
+		if deleted, err := isTombstoned(tx, userID, id); err != nil || deleted {
+			return SyncMutationResult{Status: "conflict"}, err
+		}
+
+		goalID, err := uuid.Parse(m.Excuse.GoalID)
+		if err != nil {
+			return rejected("入力内容が正しくありません"), nil
+		}
+		if err := tx.First(&goal, "id = ? AND user_id = ?", goalID, userID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return rejected("目標が見つかりません"), nil
+			}
+			return SyncMutationResult{}, err
+		}
+		if ok, _, message := checkExcuseDate(c, tx, goal, m.Excuse.Date, entitlements); !ok {
+			return rejected(message), nil
+		}
+	}
+
+	if m.Excuse.TemplateID != "" {
+		var tmpl models.ExcuseTemplate
+		if err := tx.First(&tmpl, "id = ?", m.Excuse.TemplateID).Error; err != nil {
+			return rejected("入力内容が正しくありません"), nil
+		}
+		if tmpl.IsPremium && !entitlements.CanUsePremiumTemplates {
+			return rejected("プレミアムテンプレートを利用するにはプレミアムプランが必要です"), nil
+		}
+	}
+
+	moderation, err := h.moderator.Moderate(m.Excuse.ExcuseText)
+	if err != nil {
+		return SyncMutationResult{}, err
+	}
+	if moderation.Rejected {
+		return rejected("不適切な表現が含まれています"), nil
+	}
+
+	var templateID *string
+	if m.Excuse.TemplateID != "" {
+		templateID = &m.Excuse.TemplateID
+	}
+
+	if exists {
+		// The goal and date of an excuse never change
+		before := excuse
+		if moderation.Text != excuse.ExcuseText {
+			// The excuse is no longer the text the AI generated
+			excuse.AiGenerationID = nil
+		}
+		excuse.ExcuseText = moderation.Text
+		excuse.TemplateID = templateID
+		// Clients that do not know the mood, tags and note leave them as they are
+		if m.Excuse.Mood != nil {
+			excuse.Mood = m.Excuse.Mood
+		}
+		if m.Excuse.Tags != nil {
+			excuse.Tags = normalizeTags(m.Excuse.Tags)
+		}
+		if m.Excuse.Note != nil {
+			excuse.Note = nilIfEmpty(m.Excuse.Note)
+		}
+		excuse.UpdatedAt = time.Now()
+		if err := recordExcuseRevision(tx, before, excuse); err != nil {
+			return SyncMutationResult{}, err
+		}
+		if err := tx.Save(&excuse).Error; err != nil {
+			return SyncMutationResult{}, err
+		}
+	} else {
+		// For a goal taking one excuse a day, when another device already wrote this day, the last write wins
+		// and the server's ID is kept
+		excuse, _, err = saveExcuseEntry(tx, goal, models.ExcuseEntry{
+			ID:         id,
+			UserID:     userID,
+			GoalID:     uuid.MustParse(m.Excuse.GoalID),
+			Date:       models.Date(m.Excuse.Date),
+			ExcuseText: moderation.Text,
+			TemplateID: templateID,
+			Mood:       m.Excuse.Mood,
+			Tags:       normalizeTags(m.Excuse.Tags),
+			Note:       nilIfEmpty(m.Excuse.Note),
+		})
+		if errors.Is(err, errDailyExcuseLimitReached) {
+			return rejected(dailyExcuseLimitMessage(goal)), nil
+		}
+		if err != nil {
+			return SyncMutationResult{}, err
+		}
+	}
+
+	if moderation.Flagged {
+		if err := enqueueModerationReview(tx, userID, "excuse", &excuse.ID, moderation); err != nil {
+			return SyncMutationResult{}, err
+		}
+	}
+
+	res := mapToResponse(excuse)
+	return SyncMutationResult{Status: "applied", Excuse: &res}, nil
+}
+
+func (h *SyncHandler) deleteExcuse(tx *gorm.DB, userID string, id uuid.UUID, m SyncMutation) (SyncMutationResult, error) {
+	var excuse models.ExcuseEntry
+	if err := tx.First(&excuse, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Already deleted
+			return SyncMutationResult{Status: "applied"}, nil
+		}
+		return SyncMutationResult{}, err
+	}
+	if excuse.UserID != userID {
+		return rejected("言い訳が見つかりません"), nil
+	}
+	if m.BaseVersion != nil && *m.BaseVersion != excuse.Version {
+		res := mapToResponse(excuse)
+		return SyncMutationResult{Status: "conflict", Excuse: &res}, nil
+	}
+
+	if err := deleteExcuseEntry(tx, excuse); err != nil {
+		return SyncMutationResult{}, err
+	}
+	return SyncMutationResult{Status: "applied"}, nil
+}
+
+// isTombstoned reports whether the row was deleted on the server, so an offline edit must not bring it back.
+func isTombstoned(tx *gorm.DB, userID string, id uuid.UUID) (bool, error) {
+	var count int64
+	err := tx.Model(&models.Tombstone{}).Where("user_id = ? AND entity_id = ?", userID, id).Count(&count).Error
+	return count > 0, err
+}
+
+func rejected(message string) SyncMutationResult {
+	return SyncMutationResult{Status: "rejected", Error: message}
+}
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := "auth0|test"
+	entitlements := services.Entitlements{MaxGoals: 3}
+
+	pull := func(handler *SyncHandler, cursor string) SyncPullResponse {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", entitlements)
+		c.Request, _ = http.NewRequest("GET", "/sync?cursor="+cursor, nil)
+		handler.GetSync(c)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp SyncPullResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+
+	push := func(handler *SyncHandler, body string) SyncPushResponse {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", entitlements)
+		c.Request, _ = http.NewRequest("POST", "/sync", strings.NewReader(body))
+		handler.PostSync(c)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp SyncPushResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+
+	t.Run("PullReturnsChangesAndDeletions", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewSyncHandler(db, services.NewRuleModerator(nil))
+
+		goal := models.Goal{UserID: userID, Title: "Goal", CreatedAt: time.Now().AddDate(0, 0, -7)}
+		db.Create(&goal)
+		excuse := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: models.Date(testToday()), ExcuseText: "Excuse"}
+		db.Create(&excuse)
+		db.Create(&models.Goal{UserID: "auth0|other", Title: "Other"})
+
+		first := pull(handler, "")
+		assert.Len(t, first.Goals, 1)
+		assert.Len(t, first.Excuses, 1)
+		assert.Empty(t, first.Deletions)
+
+		// Nothing changed since the first pull
+		again := pull(handler, first.Cursor)
+		assert.Empty(t, again.Goals)
+		assert.Empty(t, again.Excuses)
+		assert.Equal(t, first.Cursor, again.Cursor)
+
+		require.NoError(t, deleteExcuseEntry(db, excuse))
+		next := pull(handler, first.Cursor)
+		require.Len(t, next.Deletions, 1)
+		assert.Equal(t, "excuse", next.Deletions[0].Type)
+		assert.Equal(t, excuse.ID, next.Deletions[0].ID)
+	})
+
+	t.Run("PushDetectsConflicts", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewSyncHandler(db, services.NewRuleModerator(nil))
+
+		goalID := uuid.New().String()
+		resp := push(handler, `{"mutations": [
+			{"clientMutationId": "m-1", "type": "upsert_goal", "id": "`+goalID+`", "goal": {"title": "Offline goal"}},
+			{"clientMutationId": "m-2", "type": "upsert_goal", "id": "`+goalID+`", "baseVersion": 1, "goal": {"title": "Renamed"}},
+			{"clientMutationId": "m-3", "type": "upsert_goal", "id": "`+goalID+`", "baseVersion": 1, "goal": {"title": "Stale"}}
+		]}`)
+		require.Len(t, resp.Results, 3)
+		assert.Equal(t, "applied", resp.Results[0].Status)
+		assert.Equal(t, goalID, resp.Results[0].Goal.ID)
+		assert.Equal(t, "applied", resp.Results[1].Status)
+		assert.Equal(t, 2, resp.Results[1].Goal.Version)
+		assert.Equal(t, "conflict", resp.Results[2].Status)
+		assert.Equal(t, "Renamed", resp.Results[2].Goal.Title)
+		assert.Equal(t, "m-3", resp.Results[2].ClientMutationID)
+
+		// A deleted goal is not brought back by an offline edit
+		resp = push(handler, `{"mutations": [
+			{"type": "delete_goal", "id": "`+goalID+`"},
+			{"type": "upsert_goal", "id": "`+goalID+`", "goal": {"title": "Edited offline"}}
+		]}`)
+		assert.Equal(t, "applied", resp.Results[0].Status)
+		assert.Equal(t, "conflict", resp.Results[1].Status)
+	})
+
+	t.Run("PushKeepsOmittedExcuseFields", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewSyncHandler(db, services.NewRuleModerator(nil))
+
+		goal := models.Goal{UserID: userID, Title: "Goal", CreatedAt: time.Now().AddDate(0, 0, -7)}
+		db.Create(&goal)
+		mood, note := 4, "雨だった"
+		excuse := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: models.Date(testToday()), ExcuseText: "Excuse", Mood: &mood, Tags: pq.StringArray{"天気"}, Note: &note}
+		db.Create(&excuse)
+		mutation := func(fields string) string {
+			return `{"mutations": [{"type": "upsert_excuse", "id": "` + excuse.ID.String() + `", "excuse": {"goalId": "` + goal.ID.String() + `", "date": "` + testToday() + `", "excuseText": "Edited"` + fields + `}}]}`
+		}
+
+		resp := push(handler, mutation(""))
+		require.Equal(t, "applied", resp.Results[0].Status)
+		var saved models.ExcuseEntry
+		db.First(&saved, "id = ?", excuse.ID)
+		assert.Equal(t, "Edited", saved.ExcuseText)
+		assert.Equal(t, 4, *saved.Mood)
+		assert.Equal(t, pq.StringArray{"天気"}, saved.Tags)
+		assert.Equal(t, "雨だった", *saved.Note)
+
+		resp = push(handler, mutation(`, "tags": [], "note": ""`))
+		require.Equal(t, "applied", resp.Results[0].Status)
+		saved = models.ExcuseEntry{}
+		db.First(&saved, "id = ?", excuse.ID)
+		assert.Equal(t, 4, *saved.Mood)
+		assert.Empty(t, saved.Tags)
+		assert.Nil(t, saved.Note)
+	})
+
+	t.Run("PushRejectsLoweringDailyCap", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewSyncHandler(db, services.NewRuleModerator(nil))
+
+		goal := models.Goal{UserID: userID, Title: "Goal", MaxExcusesPerDay: 2}
+		db.Create(&goal)
+		db.Create(&models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-01", Slot: 0, ExcuseText: "Morning"})
+		db.Create(&models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-01", Slot: 1, ExcuseText: "Evening"})
+
+		resp := push(handler, `{"mutations": [
+			{"clientMutationId": "m-1", "type": "upsert_goal", "id": "`+goal.ID.String()+`", "goal": {"title": "Renamed", "maxExcusesPerDay": 1}}
+		]}`)
+		require.Len(t, resp.Results, 1)
+		assert.Equal(t, "rejected", resp.Results[0].Status)
+
+		var saved models.Goal
+		db.First(&saved, "id = ?", goal.ID)
+		assert.Equal(t, "Goal", saved.Title)
+		assert.Equal(t, 2, saved.MaxExcusesPerDay)
+	})
+
+	t.Run("PushRejectsFutureExcuse", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewSyncHandler(db, services.NewRuleModerator(nil))
+
+		goal := models.Goal{UserID: userID, Title: "Goal", CreatedAt: time.Now().AddDate(0, 0, -7)}
+		db.Create(&goal)
+		tomorrow := time.Now().In(services.LoadLocation(services.DefaultTimeZone)).AddDate(0, 0, 1).Format("2006-01-02")
+
+		resp := push(handler, `{"mutations": [
+			{"type": "upsert_excuse", "id": "`+uuid.New().String()+`", "excuse": {"goalId": "`+goal.ID.String()+`", "date": "`+testToday()+`", "excuseText": "Today"}},
+			{"type": "upsert_excuse", "id": "`+uuid.New().String()+`", "excuse": {"goalId": "`+goal.ID.String()+`", "date": "`+tomorrow+`", "excuseText": "Tomorrow"}}
+		]}`)
+		assert.Equal(t, "applied", resp.Results[0].Status)
+		assert.Equal(t, "rejected", resp.Results[1].Status)
+		assert.NotEmpty(t, resp.Results[1].Error)
+
+		var count int64
+		db.Model(&models.ExcuseEntry{}).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SyncDeletion struct {
+	Type      string    `json:"type" example:"excuse"` // "goal", "excuse"
+	ID        uuid.UUID `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type SyncPullResponse struct {
+	Goals     []GoalResponse   `json:"goals"`
+	Excuses   []ExcuseResponse `json:"excuses"`
+	Deletions []SyncDeletion   `json:"deletions"`
+	Cursor    string           `json:"cursor" example:"1024"` // Pass to the next pull
+}
+
+type SyncGoalInput struct {
+	Title               string  `json:"title" binding:"required,max=200" example:"本を10ページ読む"`
+	NotificationTime    *string `json:"notificationTime" example:"20:00"`
+	NotificationEnabled bool    `json:"notificationEnabled" example:"true"`
+	Icon                *string `json:"icon" binding:"omitempty,max=16" example:"📚"`
+	Color               *string `json:"color" binding:"omitempty,max=32" example:"blue"`
+	CoverImageURL       *string `json:"coverImageUrl" binding:"omitempty,max=2048,url,startswith=https://" example:"https://example.com/covers/books.jpg"`
+	StartDate           *string `json:"startDate" binding:"omitempty,datetime=2006-01-02" format:"date" example:"2023-10-01"`
+	EndDate             *string `json:"endDate" binding:"omitempty,datetime=2006-01-02" format:"date" example:"2023-10-30"`
+	MaxExcusesPerDay    *int    `json:"maxExcusesPerDay" binding:"omitempty,min=1,max=10" example:"1"` // Unchanged when omitted, 1 for a new goal
+}
+
+type SyncExcuseInput struct {
+	GoalID     string   `json:"goalId" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440001"`
+	Date       Date     `json:"date" binding:"required" swaggertype:"string" format:"date" example:"2023-10-27"`
+	ExcuseText string   `json:"excuseText" binding:"required,max=500" example:"寝坊しました。"`
+	TemplateID string   `json:"templateId" example:"template_123"`
+	Mood       *int     `json:"mood" binding:"omitempty,min=1,max=5" example:"3"`            // Unchanged when omitted
+	Tags       []string `json:"tags" binding:"omitempty,max=10,dive,max=30" example:"仕事,体調"` // Unchanged when omitted, [] clears them
+	Note       *string  `json:"note" binding:"omitempty,max=1000" example:"次は前日に準備する"`       // Unchanged when omitted, "" clears it
+}
+
+type SyncMutation struct {
+	ClientMutationID string           `json:"clientMutationId" example:"m-1"` // Echoed back in the result
+	Type             string           `json:"type" binding:"required,oneof=upsert_goal delete_goal upsert_excuse delete_excuse" example:"upsert_excuse"`
+	ID               string           `json:"id" binding:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	BaseVersion      *int             `json:"baseVersion" example:"2"` // Version the client edited; omit for last-write-wins
+	Goal             *SyncGoalInput   `json:"goal"`                    // For upsert_goal
+	Excuse           *SyncExcuseInput `json:"excuse"`                  // For upsert_excuse
+}
+
+type SyncPushRequest struct {
+	Mutations []SyncMutation `json:"mutations" binding:"required,max=100,dive"`
+}
+
+type SyncMutationResult struct {
+	ClientMutationID string          `json:"clientMutationId" example:"m-1"`
+	Status           string          `json:"status" example:"applied"` // "applied", "conflict", "rejected"
+	Error            string          `json:"error,omitempty" example:"未来の日付には保存できません"`
+	Goal             *GoalResponse   `json:"goal,omitempty"`   // Row after the change, or the current row on conflict
+	Excuse           *ExcuseResponse `json:"excuse,omitempty"` // Row after the change, or the current row on conflict
+}
+
+type SyncPushResponse struct {
+	Results []SyncMutationResult `json:"results"`
+}
+
+type SyncValidationErrorResponse struct {
+	Error string `json:"error" example:"入力内容が正しくありません"`
+}
+
+type SyncUnauthorizedResponse struct {
+	Error string `json:"error" example:"認証されていません"`
+}
+
+type SyncErrorResponse struct {
+	Error string `json:"error" example:"同期に失敗しました"`
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"what-went-wrong-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ToneHandler struct {
+	db *gorm.DB
+}
+
+func NewToneHandler(db *gorm.DB) *ToneHandler {
+	return &ToneHandler{db: db}
+}
+
+// GetAiTones godoc
+// @Summary List tones
+// @Description List the tones usable for AI excuses and template filtering. Labels follow Accept-Language, or the user's locale when it is not sent.
+// @Tags ai
+// @Accept json
+// @Produce json
+// @Param Accept-Language header string false "Language of labels (ja, en)"
+// @Success 200 {object} GetTonesResponse
+// @Failure 500 {object} ToneFetchErrorResponse
+// @Security BearerAuth
+// @Router /ai-tones [get]
+func (h *ToneHandler) GetAiTones(c *gin.Context) {
+	var tones []models.Tone
+	if err := h.db.Where("is_active = ?", true).Order("\"order\" asc").Find(&tones).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "トーンの取得に失敗しました"})
+		return
+	}
+
+	english := userLocale(c) == "en"
+
+	res := GetTonesResponse{Tones: make([]ToneResponse, len(tones))}
+	for i, t := range tones {
+		label := t.LabelJa
+		if english {
+			label = t.LabelEn
+		}
+		res.Tones[i] = ToneResponse{
+			ID:        t.ID,
+			Label:     label,
+			IsPremium: t.IsPremium,
+		}
+	}
+	c.JSON(http.StatusOK, res)
+}
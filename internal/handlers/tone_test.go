@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"what-went-wrong-api/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAiTones(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, cleanup := SetupTestDB(t)
+	defer cleanup()
+	handler := NewToneHandler(db)
+
+	db.Create(&models.Tone{ID: "surreal", LabelJa: "シュール", LabelEn: "Surreal", PromptInstructions: "p", IsPremium: true, IsActive: true, Order: 2})
+	db.Create(&models.Tone{ID: "casual", LabelJa: "ゆるい", LabelEn: "Casual", PromptInstructions: "p", IsActive: true, Order: 1})
+	// Inactive tones are hidden. IsActive is set after create since false is the zero value.
+	retired := models.Tone{ID: "retired", LabelJa: "廃止", LabelEn: "Retired", PromptInstructions: "p", Order: 3}
+	db.Create(&retired)
+	db.Model(&retired).Update("is_active", false)
+
+	t.Run("Japanese", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/ai-tones", nil)
+
+		handler.GetAiTones(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp GetTonesResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Len(t, resp.Tones, 2)
+		assert.Equal(t, "casual", resp.Tones[0].ID)
+		assert.Equal(t, "ゆるい", resp.Tones[0].Label)
+		assert.True(t, resp.Tones[1].IsPremium)
+	})
+
+	t.Run("English", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request, _ = http.NewRequest("GET", "/ai-tones", nil)
+		c.Request.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+		handler.GetAiTones(c)
+
+		var resp GetTonesResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, "Casual", resp.Tones[0].Label)
+	})
+}
@@ -0,0 +1,15 @@
+package handlers
+
+type ToneResponse struct {
+	ID        string `json:"id" example:"surreal"`
+	Label     string `json:"label" example:"シュール"`
+	IsPremium bool   `json:"isPremium" example:"true"`
+}
+
+type GetTonesResponse struct {
+	Tones []ToneResponse `json:"tones"`
+}
+
+type ToneFetchErrorResponse struct {
+	Error string `json:"error" example:"トーンの取得に失敗しました"`
+}
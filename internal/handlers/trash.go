@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TrashHandler struct {
+	db        *gorm.DB
+	retention time.Duration
+}
+
+// NewTrashHandler takes the retention of the purge job, to tell clients when trashed items go away.
+func NewTrashHandler(db *gorm.DB, retention time.Duration) *TrashHandler {
+	return &TrashHandler{db: db, retention: retention}
+}
+
+// GetTrash godoc
+// @Summary List the trash
+// @Description Return the deleted goals and excuses that can still be restored, most recently deleted first.
+// @Tags trash
+// @Produce json
+// @Success 200 {object} TrashResponse
+// @Failure 401 {object} TrashUnauthorizedResponse
+// @Failure 500 {object} TrashErrorResponse
+// @Security BearerAuth
+// @Router /trash [get]
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var goals []models.Goal
+	err := h.db.Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at desc").
+		Find(&goals).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ゴミ箱の取得に失敗しました"})
+		return
+	}
+
+	var excuses []models.ExcuseEntry
+	err = h.db.Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Where("goal_id IN (?)", h.db.Model(&models.Goal{}).Select("id").Where("user_id = ?", userID)).
+		Order("deleted_at desc").
+		Find(&excuses).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ゴミ箱の取得に失敗しました"})
+		return
+	}
+
+	res := TrashResponse{
+		Goals:   make([]TrashedGoalResponse, len(goals)),
+		Excuses: make([]TrashedExcuseResponse, len(excuses)),
+	}
+	for i, g := range goals {
+		res.Goals[i] = TrashedGoalResponse{
+			GoalResponse: mapToGoalResponse(g),
+			DeletedAt:    g.DeletedAt.Time,
+			PurgeAt:      g.DeletedAt.Time.Add(h.retention),
+		}
+	}
+	for i, e := range excuses {
+		res.Excuses[i] = TrashedExcuseResponse{
+			ExcuseResponse: mapToResponse(e),
+			DeletedAt:      e.DeletedAt.Time,
+			PurgeAt:        e.DeletedAt.Time.Add(h.retention),
+		}
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// PostRestoreGoal godoc
+// @Summary Restore a goal
+// @Description Take the goal out of the trash, with the excuses deleted together with it. Restored goals count toward the plan's goal limit.
+// @Tags trash
+// @Produce json
+// @Param id path string true "Goal ID" format:uuid
+// @Success 200 {object} GoalResponse
+// @Failure 400 {object} TrashValidationErrorResponse
+// @Failure 401 {object} TrashUnauthorizedResponse
+// @Failure 403 {object} GoalLimitReachedResponse
+// @Failure 404 {object} TrashNotFoundResponse
+// @Failure 500 {object} RestoreErrorResponse
+// @Security BearerAuth
+// @Router /goals/{id}/restore [post]
+func (h *TrashHandler) PostRestoreGoal(c *gin.Context) {
+	goalID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	entitlementsInterface, exists := c.Get("entitlements")
+	if !exists {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "プラン情報の取得に失敗しました"})
+		return
+	}
+	entitlements := entitlementsInterface.(services.Entitlements)
+
+	var goal models.Goal
+	if err := h.db.Unscoped().First(&goal, "id = ? AND user_id = ? AND deleted_at IS NOT NULL", goalID, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ゴミ箱に見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "復元に失敗しました"})
+		return
+	}
+
+	count, err := countActiveGoals(h.db, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "復元に失敗しました"})
+		return
+	}
+	if int(count) >= entitlements.MaxGoals {
+		c.JSON(http.StatusForbidden, gin.H{"error": "プランの目標作成数上限に達しました"})
+		return
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		return restoreGoal(tx, &goal)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "復元に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapToGoalResponse(goal))
+}
+
+// PostRestoreExcuse godoc
+// @Summary Restore an excuse
+// @Description Take the excuse out of the trash. Fails with 409 when its goal is in the trash (restore the goal instead) or the goal's daily cap has since been lowered below it. Saving a new excuse for the same day permanently replaces the one in the trash.
+// @Tags trash
+// @Produce json
+// @Param id path string true "Excuse ID" format:uuid
+// @Success 200 {object} ExcuseResponse
+// @Failure 400 {object} TrashValidationErrorResponse
+// @Failure 401 {object} TrashUnauthorizedResponse
+// @Failure 404 {object} TrashNotFoundResponse
+// @Failure 409 {object} TrashConflictResponse
+// @Failure 500 {object} RestoreErrorResponse
+// @Security BearerAuth
+// @Router /excuses/{id}/restore [post]
+func (h *TrashHandler) PostRestoreExcuse(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var excuse models.ExcuseEntry
+	if err := h.db.Unscoped().First(&excuse, "id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ゴミ箱に見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "復元に失敗しました"})
+		return
+	}
+
+	var goal models.Goal
+	if err := h.db.First(&goal, "id = ?", excuse.GoalID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusConflict, gin.H{"error": "先に目標を復元してください"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "復元に失敗しました"})
+		return
+	}
+	// The daily cap may have been lowered since the excuse was deleted
+	if excuse.Slot >= goal.MaxExcusesPerDay {
+		c.JSON(http.StatusConflict, gin.H{"error": dailyExcuseLimitMessage(goal)})
+		return
+	}
+
+	err = h.db.Transaction(func(tx *gorm.DB) error {
+		return restoreExcuseEntry(tx, &excuse)
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "復元に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapToResponse(excuse))
+}
+
+// restoreGoal takes the goal out of the trash with the excuses deleted together with it (see deleteGoal).
+func restoreGoal(tx *gorm.DB, goal *models.Goal) error {
+	var excuses []models.ExcuseEntry
+	err := tx.Unscoped().
+		Where("goal_id = ? AND deleted_at >= ?", goal.ID, goal.DeletedAt.Time).
+		Find(&excuses).Error
+	if err != nil {
+		return err
+	}
+
+	goal.DeletedAt = gorm.DeletedAt{}
+	if err := tx.Unscoped().Save(goal).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("entity_type = ? AND entity_id = ?", "goal", goal.ID).Delete(&models.Tombstone{}).Error; err != nil {
+		return err
+	}
+
+	for i := range excuses {
+		if err := restoreExcuseEntry(tx, &excuses[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreExcuseEntry takes the excuse out of the trash. Saving it gives it a new sync sequence and
+// removing its tombstone keeps delta sync from deleting it again.
+func restoreExcuseEntry(tx *gorm.DB, excuse *models.ExcuseEntry) error {
+	excuse.DeletedAt = gorm.DeletedAt{}
+	if err := tx.Unscoped().Save(excuse).Error; err != nil {
+		return err
+	}
+	return tx.Where("entity_type = ? AND entity_id = ?", "excuse", excuse.ID).Delete(&models.Tombstone{}).Error
+}
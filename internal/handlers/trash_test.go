@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrash(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := "auth0|test"
+	retention := 30 * 24 * time.Hour
+
+	getTrash := func(handler *TrashHandler) TrashResponse {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Request, _ = http.NewRequest("GET", "/trash", nil)
+		handler.GetTrash(c)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var resp TrashResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+
+	restore := func(handle gin.HandlerFunc, id string, entitlements services.Entitlements) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Set("entitlements", entitlements)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		c.Request, _ = http.NewRequest("POST", "/"+id+"/restore", nil)
+		handle(c)
+		return w
+	}
+
+	t.Run("RestoreGoalWithItsExcuses", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewTrashHandler(db, retention)
+
+		goal := models.Goal{UserID: userID, Title: "Goal", CreatedAt: time.Now().AddDate(0, 0, -7)}
+		db.Create(&goal)
+		deletedEarlier := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-01", ExcuseText: "Deleted on its own"}
+		db.Create(&deletedEarlier)
+		deletedWithGoal := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-02", ExcuseText: "Deleted with the goal"}
+		db.Create(&deletedWithGoal)
+
+		require.NoError(t, deleteExcuseEntry(db, deletedEarlier))
+		require.NoError(t, deleteGoal(db, goal))
+
+		trash := getTrash(handler)
+		require.Len(t, trash.Goals, 1)
+		assert.Equal(t, goal.ID.String(), trash.Goals[0].ID)
+		assert.WithinDuration(t, trash.Goals[0].DeletedAt.Add(retention), trash.Goals[0].PurgeAt, time.Second)
+		// Excuses of a trashed goal are restored with it
+		assert.Empty(t, trash.Excuses)
+
+		// Trashed goals do not count toward the limit, restored ones do
+		db.Create(&models.Goal{UserID: userID, Title: "Other"})
+		w := restore(handler.PostRestoreGoal, goal.ID.String(), services.Entitlements{MaxGoals: 1})
+		assert.Equal(t, http.StatusForbidden, w.Code)
+
+		w = restore(handler.PostRestoreGoal, goal.ID.String(), services.Entitlements{MaxGoals: 3})
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var count int64
+		db.Model(&models.ExcuseEntry{}).Where("goal_id = ?", goal.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+		db.Model(&models.Tombstone{}).Where("entity_id IN ?", []any{goal.ID, deletedWithGoal.ID}).Count(&count)
+		assert.Equal(t, int64(0), count)
+
+		trash = getTrash(handler)
+		assert.Empty(t, trash.Goals)
+		require.Len(t, trash.Excuses, 1)
+		assert.Equal(t, deletedEarlier.ID, trash.Excuses[0].ID)
+	})
+
+	t.Run("RestoreExcuse", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewTrashHandler(db, retention)
+
+		goal := models.Goal{UserID: userID, Title: "Goal"}
+		db.Create(&goal)
+		excuse := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-01", ExcuseText: "Excuse"}
+		db.Create(&excuse)
+		require.NoError(t, deleteExcuseEntry(db, excuse))
+
+		w := restore(handler.PostRestoreExcuse, excuse.ID.String(), services.Entitlements{})
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp ExcuseResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, 2, resp.Version)
+
+		// No longer in the trash
+		w = restore(handler.PostRestoreExcuse, excuse.ID.String(), services.Entitlements{})
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("NewExcuseReplacesTrashedOne", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+
+		goal := models.Goal{UserID: userID, Title: "Goal"}
+		db.Create(&goal)
+		excuse := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-01", ExcuseText: "Old"}
+		db.Create(&excuse)
+		require.NoError(t, deleteExcuseEntry(db, excuse))
+
+		_, created, err := upsertExcuseEntry(db, models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-01", ExcuseText: "New"})
+		require.NoError(t, err)
+		assert.True(t, created)
+
+		var count int64
+		db.Unscoped().Model(&models.ExcuseEntry{}).Where("goal_id = ?", goal.ID).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("RestoreExcuseAboveLoweredCap", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+		handler := NewTrashHandler(db, retention)
+
+		goal := models.Goal{UserID: userID, Title: "Goal", MaxExcusesPerDay: 2}
+		db.Create(&goal)
+		excuse := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-01", Slot: 1, ExcuseText: "Evening"}
+		db.Create(&excuse)
+		require.NoError(t, deleteExcuseEntry(db, excuse))
+		db.Model(&goal).Update("max_excuses_per_day", 1)
+
+		w := restore(handler.PostRestoreExcuse, excuse.ID.String(), services.Entitlements{})
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("PurgeExpired", func(t *testing.T) {
+		db, cleanup := SetupTestDB(t)
+		defer cleanup()
+
+		expired := models.Goal{UserID: userID, Title: "Expired"}
+		db.Create(&expired)
+		db.Create(&models.ExcuseEntry{UserID: userID, GoalID: expired.ID, Date: "2025-01-01", ExcuseText: "Excuse"})
+		recent := models.Goal{UserID: userID, Title: "Recent"}
+		db.Create(&recent)
+		require.NoError(t, deleteGoal(db, expired))
+		require.NoError(t, deleteGoal(db, recent))
+		db.Unscoped().Model(&models.Goal{}).Where("id = ?", expired.ID).Update("deleted_at", time.Now().Add(-retention-time.Hour))
+
+		require.NoError(t, services.NewTrashService(db, retention).PurgeExpired())
+
+		var ids []string
+		db.Unscoped().Model(&models.Goal{}).Pluck("id", &ids)
+		assert.Equal(t, []string{recent.ID.String()}, ids)
+		var count int64
+		db.Unscoped().Model(&models.ExcuseEntry{}).Count(&count)
+		assert.Equal(t, int64(0), count)
+	})
+}
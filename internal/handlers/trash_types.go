@@ -0,0 +1,44 @@
+package handlers
+
+import "time"
+
+type TrashedGoalResponse struct {
+	GoalResponse
+	DeletedAt time.Time `json:"deletedAt"`
+	PurgeAt   time.Time `json:"purgeAt"` // Permanently deleted after this time
+}
+
+type TrashedExcuseResponse struct {
+	ExcuseResponse
+	DeletedAt time.Time `json:"deletedAt"`
+	PurgeAt   time.Time `json:"purgeAt"` // Permanently deleted after this time
+}
+
+type TrashResponse struct {
+	Goals   []TrashedGoalResponse   `json:"goals"`
+	Excuses []TrashedExcuseResponse `json:"excuses"` // Excuses deleted on their own; those of a trashed goal are restored with it
+}
+
+type TrashValidationErrorResponse struct {
+	Error string `json:"error" example:"入力内容が正しくありません"`
+}
+
+type TrashUnauthorizedResponse struct {
+	Error string `json:"error" example:"認証されていません"`
+}
+
+type TrashNotFoundResponse struct {
+	Error string `json:"error" example:"ゴミ箱に見つかりません"`
+}
+
+type TrashConflictResponse struct {
+	Error string `json:"error" example:"先に目標を復元してください"`
+}
+
+type TrashErrorResponse struct {
+	Error string `json:"error" example:"ゴミ箱の取得に失敗しました"`
+}
+
+type RestoreErrorResponse struct {
+	Error string `json:"error" example:"復元に失敗しました"`
+}
@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserManager interface {
+	GetOrCreateUser(userID string, timeZone string, locale string) (*models.User, error)
+	UpdateTimeZone(userID string, timeZone string) (*models.User, error)
+	UpdateProfile(userID string, update services.UserProfileUpdate) (*models.User, error)
+}
+
+type UserHandler struct {
+	userService UserManager
+}
+
+func NewUserHandler(userService UserManager) *UserHandler {
+	return &UserHandler{userService: userService}
+}
+
+// GetMe godoc
+// @Summary Get profile
+// @Description Returns the user's profile and preferences. The user is created on the first authenticated request.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} UserResponse
+// @Failure 401 {object} UserUnauthorizedResponse
+// @Failure 500 {object} UserFetchErrorResponse
+// @Security BearerAuth
+// @Router /me [get]
+func (h *UserHandler) GetMe(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	user, err := h.userService.GetOrCreateUser(userID, c.GetHeader("X-Time-Zone"), services.LocaleFromAcceptLanguage(c.GetHeader("Accept-Language")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザー情報の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapToUserResponse(*user))
+}
+
+// PatchMe godoc
+// @Summary Update profile
+// @Description Update the given profile and preference fields. Omitted fields are left as they are.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body UpdateUserRequest true "Request body"
+// @Success 200 {object} UserResponse
+// @Failure 400 {object} UserValidationErrorResponse
+// @Failure 401 {object} UserUnauthorizedResponse
+// @Failure 500 {object} UserUpdateErrorResponse
+// @Security BearerAuth
+// @Router /me [patch]
+func (h *UserHandler) PatchMe(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var req UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(userID, services.UserProfileUpdate{
+		DisplayName:                  req.DisplayName,
+		Locale:                       req.Locale,
+		TimeZone:                     req.TimeZone,
+		WeekStartDay:                 req.WeekStartDay,
+		ReminderNotificationsEnabled: req.ReminderNotificationsEnabled,
+		NewsNotificationsEnabled:     req.NewsNotificationsEnabled,
+		OnboardingCompleted:          req.OnboardingCompleted,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTimeZone) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザー情報の更新に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, mapToUserResponse(*user))
+}
+
+// GetMeTimeZone godoc
+// @Summary Get time zone
+// @Description Returns the user's time zone, used for "today", log retention, date validation and notification times. Defaults to the X-Time-Zone header of the first request.
+// @Tags user
+// @Accept json
+// @Produce json
+// @Success 200 {object} TimeZoneResponse
+// @Failure 401 {object} UserUnauthorizedResponse
+// @Failure 500 {object} UserFetchErrorResponse
+// @Security BearerAuth
+// @Router /me/timezone [get]
+func (h *UserHandler) GetMeTimeZone(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	user, err := h.userService.GetOrCreateUser(userID, c.GetHeader("X-Time-Zone"), services.LocaleFromAcceptLanguage(c.GetHeader("Accept-Language")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザー情報の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, TimeZoneResponse{TimeZone: user.TimeZone})
+}
+
+// PutMeTimeZone godoc
+// @Summary Update time zone
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body UpdateTimeZoneRequest true "Request body"
+// @Success 200 {object} TimeZoneResponse
+// @Failure 400 {object} UserValidationErrorResponse "Unknown time zone"
+// @Failure 401 {object} UserUnauthorizedResponse
+// @Failure 500 {object} UserUpdateErrorResponse
+// @Security BearerAuth
+// @Router /me/timezone [put]
+func (h *UserHandler) PutMeTimeZone(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "認証されていません"})
+		return
+	}
+	userID := userIDStr.(string)
+
+	var req UpdateTimeZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+		return
+	}
+
+	user, err := h.userService.UpdateTimeZone(userID, req.TimeZone)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTimeZone) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "入力内容が正しくありません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザー情報の更新に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, TimeZoneResponse{TimeZone: user.TimeZone})
+}
+
+func mapToUserResponse(u models.User) UserResponse {
+	return UserResponse{
+		ID:                           u.ID,
+		DisplayName:                  u.DisplayName,
+		Locale:                       u.Locale,
+		TimeZone:                     u.TimeZone,
+		WeekStartDay:                 u.WeekStartDay,
+		ReminderNotificationsEnabled: u.ReminderNotificationsEnabled,
+		NewsNotificationsEnabled:     u.NewsNotificationsEnabled,
+		OnboardingCompleted:          u.OnboardingCompleted,
+		DeletionScheduledAt:          u.DeletionScheduledAt,
+		CreatedAt:                    u.CreatedAt,
+		UpdatedAt:                    u.UpdatedAt,
+	}
+}
+
+// userLocale returns the language for the response: Accept-Language when sent, otherwise the user's locale.
+func userLocale(c *gin.Context) string {
+	if locale := services.LocaleFromAcceptLanguage(c.GetHeader("Accept-Language")); locale != "" {
+		return locale
+	}
+	if user, exists := c.Get("user"); exists {
+		return user.(*models.User).Locale
+	}
+	return services.DefaultLocale
+}
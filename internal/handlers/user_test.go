@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockUserManager struct {
+	mock.Mock
+}
+
+func (m *MockUserManager) GetOrCreateUser(userID string, timeZone string, locale string) (*models.User, error) {
+	args := m.Called(userID, timeZone, locale)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserManager) UpdateTimeZone(userID string, timeZone string) (*models.User, error) {
+	args := m.Called(userID, timeZone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserManager) UpdateProfile(userID string, update services.UserProfileUpdate) (*models.User, error) {
+	args := m.Called(userID, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func TestGetMe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockManager := new(MockUserManager)
+	handler := NewUserHandler(mockManager)
+
+	userID := "auth0|test"
+	mockManager.On("GetOrCreateUser", userID, "", "en").Return(&models.User{ID: userID, Locale: "en", TimeZone: "Asia/Tokyo", WeekStartDay: "monday", ReminderNotificationsEnabled: true}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", userID)
+	c.Request, _ = http.NewRequest("GET", "/me", nil)
+	c.Request.Header.Set("Accept-Language", "en-US,en;q=0.9")
+
+	handler.GetMe(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp UserResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, "en", resp.Locale)
+	assert.Equal(t, "monday", resp.WeekStartDay)
+	assert.True(t, resp.ReminderNotificationsEnabled)
+	assert.False(t, resp.OnboardingCompleted)
+}
+
+func TestPatchMe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := "auth0|test"
+
+	t.Run("Success", func(t *testing.T) {
+		mockManager := new(MockUserManager)
+		handler := NewUserHandler(mockManager)
+		name := "ねこ"
+		completed := true
+		mockManager.On("UpdateProfile", userID, services.UserProfileUpdate{DisplayName: &name, OnboardingCompleted: &completed}).
+			Return(&models.User{ID: userID, DisplayName: name, Locale: "ja", TimeZone: "Asia/Tokyo", OnboardingCompleted: true}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Request, _ = http.NewRequest("PATCH", "/me", strings.NewReader(`{"displayName": "ねこ", "onboardingCompleted": true}`))
+
+		handler.PatchMe(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp UserResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, "ねこ", resp.DisplayName)
+		assert.True(t, resp.OnboardingCompleted)
+	})
+
+	t.Run("InvalidValues", func(t *testing.T) {
+		for _, body := range []string{`{"locale": "fr"}`, `{"weekStartDay": "friday"}`} {
+			mockManager := new(MockUserManager)
+			handler := NewUserHandler(mockManager)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Set("userID", userID)
+			c.Request, _ = http.NewRequest("PATCH", "/me", strings.NewReader(body))
+
+			handler.PatchMe(c)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockManager.AssertNotCalled(t, "UpdateProfile", mock.Anything, mock.Anything)
+		}
+	})
+}
+
+func TestGetMeTimeZone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockManager := new(MockUserManager)
+	handler := NewUserHandler(mockManager)
+
+	userID := "auth0|test"
+	mockManager.On("GetOrCreateUser", userID, "Europe/Paris", "").Return(&models.User{ID: userID, TimeZone: "Europe/Paris"}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Set("userID", userID)
+	c.Request, _ = http.NewRequest("GET", "/me/timezone", nil)
+	c.Request.Header.Set("X-Time-Zone", "Europe/Paris")
+
+	handler.GetMeTimeZone(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp TimeZoneResponse
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	assert.Equal(t, "Europe/Paris", resp.TimeZone)
+}
+
+func TestPutMeTimeZone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	userID := "auth0|test"
+
+	t.Run("Success", func(t *testing.T) {
+		mockManager := new(MockUserManager)
+		handler := NewUserHandler(mockManager)
+		mockManager.On("UpdateTimeZone", userID, "America/New_York").Return(&models.User{ID: userID, TimeZone: "America/New_York"}, nil)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Request, _ = http.NewRequest("PUT", "/me/timezone", strings.NewReader(`{"timeZone": "America/New_York"}`))
+
+		handler.PutMeTimeZone(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp TimeZoneResponse
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.Equal(t, "America/New_York", resp.TimeZone)
+	})
+
+	t.Run("InvalidTimeZone", func(t *testing.T) {
+		mockManager := new(MockUserManager)
+		handler := NewUserHandler(mockManager)
+		mockManager.On("UpdateTimeZone", userID, "Mars/Olympus").Return(nil, services.ErrInvalidTimeZone)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Set("userID", userID)
+		c.Request, _ = http.NewRequest("PUT", "/me/timezone", strings.NewReader(`{"timeZone": "Mars/Olympus"}`))
+
+		handler.PutMeTimeZone(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
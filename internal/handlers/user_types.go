@@ -0,0 +1,51 @@
+package handlers
+
+import "time"
+
+type UserResponse struct {
+	ID                           string     `json:"id" example:"auth0|1234567890"`
+	DisplayName                  string     `json:"displayName" example:"ねこ"`
+	Locale                       string     `json:"locale" example:"ja"`
+	TimeZone                     string     `json:"timeZone" example:"Asia/Tokyo"`
+	WeekStartDay                 string     `json:"weekStartDay" example:"monday"`
+	ReminderNotificationsEnabled bool       `json:"reminderNotificationsEnabled" example:"true"`
+	NewsNotificationsEnabled     bool       `json:"newsNotificationsEnabled" example:"false"`
+	OnboardingCompleted          bool       `json:"onboardingCompleted" example:"false"`
+	DeletionScheduledAt          *time.Time `json:"deletionScheduledAt,omitempty"` // Set after DELETE /me until the account is deleted
+	CreatedAt                    time.Time  `json:"createdAt"`
+	UpdatedAt                    time.Time  `json:"updatedAt"`
+}
+
+type UpdateUserRequest struct {
+	DisplayName                  *string `json:"displayName" binding:"omitempty,max=50" example:"ねこ"`
+	Locale                       *string `json:"locale" binding:"omitempty,oneof=ja en" example:"en"`
+	TimeZone                     *string `json:"timeZone" example:"America/Los_Angeles"` // IANA name
+	WeekStartDay                 *string `json:"weekStartDay" binding:"omitempty,oneof=monday sunday" example:"sunday"`
+	ReminderNotificationsEnabled *bool   `json:"reminderNotificationsEnabled" example:"true"`
+	NewsNotificationsEnabled     *bool   `json:"newsNotificationsEnabled" example:"false"`
+	OnboardingCompleted          *bool   `json:"onboardingCompleted" example:"true"`
+}
+
+type TimeZoneResponse struct {
+	TimeZone string `json:"timeZone" example:"Asia/Tokyo"`
+}
+
+type UpdateTimeZoneRequest struct {
+	TimeZone string `json:"timeZone" binding:"required" example:"America/Los_Angeles"` // IANA name
+}
+
+type UserUnauthorizedResponse struct {
+	Error string `json:"error" example:"認証されていません"`
+}
+
+type UserValidationErrorResponse struct {
+	Error string `json:"error" example:"入力内容が正しくありません"`
+}
+
+type UserFetchErrorResponse struct {
+	Error string `json:"error" example:"ユーザー情報の取得に失敗しました"`
+}
+
+type UserUpdateErrorResponse struct {
+	Error string `json:"error" example:"ユーザー情報の更新に失敗しました"`
+}
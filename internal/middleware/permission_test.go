@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name           string
+		claims         jwt.MapClaims
+		expectedStatus int
+	}{
+		{name: "NoClaims", claims: nil, expectedStatus: http.StatusUnauthorized},
+		{name: "NoPermissions", claims: jwt.MapClaims{"sub": "auth0|test"}, expectedStatus: http.StatusForbidden},
+		{name: "OtherPermission", claims: jwt.MapClaims{"permissions": []any{"read:reports"}}, expectedStatus: http.StatusForbidden},
+		{name: "Granted", claims: jwt.MapClaims{"permissions": []any{"read:reports", "admin:catalog"}}, expectedStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tt.claims != nil {
+					c.Set("claims", tt.claims)
+				}
+			})
+			r.Use(RequirePermission("admin:catalog"))
+			r.GET("/", func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/", nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
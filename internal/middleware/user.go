@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"net/http"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserManager interface {
+	GetOrCreateUser(userID string, timeZone string, locale string) (*models.User, error)
+}
+
+// NewUserMiddleware loads the user (creating it on the first request, with the time zone
+// from the X-Time-Zone header and the locale from Accept-Language) and sets "user" and the user's "location" on the context.
+func NewUserMiddleware(service UserManager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			c.Abort()
+			return
+		}
+
+		userID := userIDStr.(string)
+
+		user, err := service.GetOrCreateUser(userID, c.GetHeader("X-Time-Zone"), services.LocaleFromAcceptLanguage(c.GetHeader("Accept-Language")))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get user"})
+			c.Abort()
+			return
+		}
+
+		c.Set("user", user)
+		c.Set("location", services.LoadLocation(user.TimeZone))
+		c.Next()
+	}
+}
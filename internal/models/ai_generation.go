@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type AiGeneration struct {
+	ID         uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     string         `gorm:"size:255;not null;index"`
+	GoalID     uuid.UUID      `gorm:"type:uuid;not null;index"`
+	Date       Date           `gorm:"type:date;not null"` // YYYY-MM-DD
+	Tone       string         `gorm:"size:255"`
+	Context    string         `gorm:"type:text"`
+	Candidates pq.StringArray `gorm:"type:text[]"`
+	Model      string         `gorm:"size:255;not null"` // "mock", etc
+	CreatedAt  time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
+}
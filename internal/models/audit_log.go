@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLog records account level events. It outlives the account, so the user is
+// identified only by a hash of the user ID.
+type AuditLog struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Action      string    `gorm:"size:50;not null"` // "account_deletion_requested", "account_deletion_cancelled", "account_deleted"
+	SubjectHash string    `gorm:"size:64;not null;index"`
+	CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DataExport is a ZIP of the user's data, built asynchronously by the export worker.
+type DataExport struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID      string    `gorm:"size:255;not null;index"`
+	Status      string    `gorm:"size:50;not null;default:'pending'"` // "pending", "processing", "completed", "failed"
+	Archive     []byte    `gorm:"type:bytea"`
+	Size        int
+	CompletedAt *time.Time
+	ExpiresAt   *time.Time // Completed archives are removed after this
+	CreatedAt   time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+// CreateExportInProgressIndex allows a single pending or processing export per user, so that concurrent
+// requests cannot start two. Duplicates created before the index are marked failed. Run it after AutoMigrate.
+func CreateExportInProgressIndex(db *gorm.DB) error {
+	err := db.Exec(`UPDATE data_exports SET status = 'failed', updated_at = now()
+		WHERE status IN ('pending', 'processing') AND id NOT IN (
+			SELECT DISTINCT ON (user_id) id FROM data_exports WHERE status IN ('pending', 'processing') ORDER BY user_id, created_at
+		)`).Error
+	if err != nil {
+		return err
+	}
+	return db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_data_exports_in_progress ON data_exports (user_id) WHERE status IN ('pending', 'processing')").Error
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Date is a calendar date stored in a Postgres date column, held as YYYY-MM-DD.
+// The driver reads date columns as time.Time, which a plain string would receive as RFC3339;
+// Date formats it once so that dates compare and print as YYYY-MM-DD everywhere.
+type Date string
+
+func (d *Date) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*d = ""
+	case time.Time:
+		*d = Date(v.Format(dateLayout))
+	case string:
+		return d.scanText(v)
+	case []byte:
+		return d.scanText(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into Date", value)
+	}
+	return nil
+}
+
+func (d *Date) scanText(s string) error {
+	if len(s) < len(dateLayout) {
+		return fmt.Errorf("invalid date %q", s)
+	}
+	t, err := time.Parse(dateLayout, s[:len(dateLayout)])
+	if err != nil {
+		return err
+	}
+	*d = Date(t.Format(dateLayout))
+	return nil
+}
+
+func (d Date) Value() (driver.Value, error) {
+	if d == "" {
+		return nil, nil
+	}
+	return string(d), nil
+}
+
+// Time returns the date at midnight UTC.
+func (d Date) Time() (time.Time, error) {
+	return time.Parse(dateLayout, string(d))
+}
+
+// DatePtr returns s as a *Date, or nil for nil.
+func DatePtr(s *string) *Date {
+	if s == nil {
+		return nil
+	}
+	d := Date(*s)
+	return &d
+}
+
+// StringPtr returns d as a *string, or nil for nil.
+func (d *Date) StringPtr() *string {
+	if d == nil {
+		return nil
+	}
+	s := string(*d)
+	return &s
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateScan(t *testing.T) {
+	t.Run("DriverTime", func(t *testing.T) {
+		// pgx hands date columns over as time.Time at midnight UTC
+		var d Date
+		assert.NoError(t, d.Scan(time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, Date("2025-01-30"), d)
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		var d Date
+		assert.NoError(t, d.Scan("2025-01-30T00:00:00Z"))
+		assert.Equal(t, Date("2025-01-30"), d)
+		assert.NoError(t, d.Scan([]byte("2025-02-01")))
+		assert.Equal(t, Date("2025-02-01"), d)
+		assert.Error(t, d.Scan("30/01/2025"))
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		v, err := Date("2025-01-30").Value()
+		assert.NoError(t, err)
+		assert.Equal(t, "2025-01-30", v)
+		v, _ = Date("").Value()
+		assert.Nil(t, v)
+	})
+}
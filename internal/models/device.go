@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Device struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     string    `gorm:"size:255;not null;index"`
+	Platform   string    `gorm:"size:50;not null"` // "ios", "android"
+	PushToken  string    `gorm:"size:512;not null;uniqueIndex"`
+	AppVersion string    `gorm:"size:50"`
+	Locale     string    `gorm:"size:50"`
+	LastSeenAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	CreatedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
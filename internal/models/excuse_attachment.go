@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExcuseAttachment is a photo attached to an excuse. The image and its thumbnail live in the blob store.
+type ExcuseAttachment struct {
+	ID            uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID        string    `gorm:"size:255;not null;index"`
+	ExcuseID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	ContentType   string    `gorm:"size:50;not null"` // "image/jpeg" or "image/png"
+	Size          int64     `gorm:"not null"`         // Bytes of the image
+	ThumbnailSize int64     `gorm:"not null"`         // Bytes of the thumbnail, counted in the storage quota too
+	Width         int       `gorm:"not null"`
+	Height        int       `gorm:"not null"`
+	BlobKey       string    `gorm:"size:255;not null"`
+	ThumbnailKey  string    `gorm:"size:255;not null"`
+	CreatedAt     time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
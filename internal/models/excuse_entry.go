@@ -4,15 +4,62 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 type ExcuseEntry struct {
-	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	UserID     string    `gorm:"size:255;not null;index;uniqueIndex:idx_user_goal_date"`
-	GoalID     uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_user_goal_date"`
-	Date       string    `gorm:"type:date;not null;uniqueIndex:idx_user_goal_date"` // YYYY-MM-DD
-	ExcuseText string    `gorm:"type:text;not null"`
-	TemplateID *string   `gorm:"size:255"`
-	CreatedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP"`
-	UpdatedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	ID         uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     string         `gorm:"size:255;not null;index;uniqueIndex:idx_user_goal_date_slot"`
+	GoalID     uuid.UUID      `gorm:"type:uuid;not null;index;uniqueIndex:idx_user_goal_date_slot"`
+	Date       Date           `gorm:"type:date;not null;uniqueIndex:idx_user_goal_date_slot"` // YYYY-MM-DD
+	Slot       int            `gorm:"not null;default:0;uniqueIndex:idx_user_goal_date_slot"` // 0 to the goal's MaxExcusesPerDay - 1
+	ExcuseText string         `gorm:"type:text;not null"`
+	TemplateID *string        `gorm:"size:255"`
+	Mood       *int           `gorm:"type:smallint"` // How bad the user feels about the miss, 1 (not at all) to 5
+	Tags       pq.StringArray `gorm:"type:text[]"`   // Reasons such as "仕事", "体調", "天気"
+	Note       *string        `gorm:"type:text"`     // Private memo, never moderated or shown to others
+	// Provenance when the text was picked from an AI generation
+	AiGenerationID *uuid.UUID     `gorm:"type:uuid;index"`
+	Version        int            `gorm:"not null;default:1"` // Incremented on every update, for conflict detection
+	SyncSeq        int64          `gorm:"not null;default:0;index"`
+	CreatedAt      time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt      time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt      gorm.DeletedAt `gorm:"index"` // Set while the excuse is in the trash
+}
+
+// DropSingleExcuseIndex drops the index that allowed one excuse per goal and day, replaced by
+// idx_user_goal_date_slot. Run it after AutoMigrate.
+func DropSingleExcuseIndex(db *gorm.DB) error {
+	if !db.Migrator().HasIndex(&ExcuseEntry{}, "idx_user_goal_date") {
+		return nil
+	}
+	return db.Migrator().DropIndex(&ExcuseEntry{}, "idx_user_goal_date")
+}
+
+func (e *ExcuseEntry) BeforeCreate(tx *gorm.DB) error {
+	e.Version = 1
+	return nil
+}
+
+func (e *ExcuseEntry) BeforeUpdate(tx *gorm.DB) error {
+	e.Version++
+	return nil
+}
+
+func (e *ExcuseEntry) BeforeSave(tx *gorm.DB) error {
+	seq, err := nextSyncSeq(tx, e.UserID)
+	e.SyncSeq = seq
+	return err
+}
+
+// CreateExcuseSearchIndex creates a trigram index on the excuse text, so that searching with ILIKE
+// does not scan every excuse. Trigrams need no word breaks, which suits Japanese. A term of one or two
+// characters has no trigram, so pg_trgm cannot use the index for it; such searches fall back to the user's
+// rows. Run it after AutoMigrate.
+func CreateExcuseSearchIndex(db *gorm.DB) error {
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return err
+	}
+	return db.Exec("CREATE INDEX IF NOT EXISTS idx_excuse_entries_text_trgm ON excuse_entries USING gin (excuse_text gin_trgm_ops)").Error
 }
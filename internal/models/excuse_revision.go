@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExcuseRevision is an earlier wording of an excuse, kept when the text or template is overwritten.
+// Revisions are never updated; they are removed after the log retention of the user's plan.
+type ExcuseRevision struct {
+	ID             uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID         string     `gorm:"size:255;not null;index"`
+	ExcuseID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ExcuseText     string     `gorm:"type:text;not null"`
+	TemplateID     *string    `gorm:"size:255"`
+	AiGenerationID *uuid.UUID `gorm:"type:uuid"`
+	WrittenAt      time.Time  `gorm:"not null"`                        // When this wording was saved
+	CreatedAt      time.Time  `gorm:"default:CURRENT_TIMESTAMP;index"` // When it was replaced
+}
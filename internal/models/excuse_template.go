@@ -13,5 +13,6 @@ type ExcuseTemplate struct {
 	IsActive  bool           `gorm:"default:true"`
 	IsPremium bool           `gorm:"default:false"`
 	Tags      pq.StringArray `gorm:"type:text[]"`
+	Tones     pq.StringArray `gorm:"type:text[]"` // Tone IDs
 	CreatedAt time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
 }
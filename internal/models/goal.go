@@ -4,15 +4,42 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Goal struct {
-	ID                  uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
-	UserID              string    `gorm:"size:255;not null;index"`
-	Title               string    `gorm:"size:255;not null"`
-	NotificationTime    *string   `gorm:"size:5"` // "HH:MM" format
-	NotificationEnabled bool      `gorm:"default:false"`
-	Order               int       `gorm:"default:0"`
-	CreatedAt           time.Time `gorm:"default:CURRENT_TIMESTAMP"`
-	UpdatedAt           time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	ID                  uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID              string         `gorm:"size:255;not null;index"`
+	Title               string         `gorm:"size:255;not null"`
+	NotificationTime    *string        `gorm:"size:5"` // "HH:MM" format
+	NotificationEnabled bool           `gorm:"default:false"`
+	Order               int            `gorm:"default:0"`
+	Icon                *string        `gorm:"size:64"`            // Emoji shown on the goal card
+	Color               *string        `gorm:"size:32"`            // Palette key or "#RRGGBB"
+	CoverImageURL       *string        `gorm:"type:text"`          // Optional image behind the goal card
+	Version             int            `gorm:"not null;default:1"` // Incremented on every update, for conflict detection
+	SyncSeq             int64          `gorm:"not null;default:0;index"`
+	StartDate           *Date          `gorm:"type:date"`          // YYYY-MM-DD. With EndDate, makes the goal a challenge over that period
+	EndDate             *Date          `gorm:"type:date"`          // YYYY-MM-DD. The goal is archived with a GoalSummary after this day
+	MaxExcusesPerDay    int            `gorm:"not null;default:1"` // 1 keeps one excuse a day, overwritten on save; more allows several up to this cap
+	ArchivedAt          *time.Time     // Archived goals keep their history but take no new excuses
+	CreatedAt           time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt           time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
+	DeletedAt           gorm.DeletedAt `gorm:"index"` // Set while the goal is in the trash
+}
+
+func (g *Goal) BeforeCreate(tx *gorm.DB) error {
+	g.Version = 1
+	return nil
+}
+
+func (g *Goal) BeforeUpdate(tx *gorm.DB) error {
+	g.Version++
+	return nil
+}
+
+func (g *Goal) BeforeSave(tx *gorm.DB) error {
+	seq, err := nextSyncSeq(tx, g.UserID)
+	g.SyncSeq = seq
+	return err
 }
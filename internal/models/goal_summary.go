@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GoalSummary is the result of a goal with an end date, written once the period is over.
+type GoalSummary struct {
+	GoalID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID      string    `gorm:"size:255;not null;index"`
+	StartDate   Date      `gorm:"type:date;not null"` // YYYY-MM-DD, the start date or the day the goal was created
+	EndDate     Date      `gorm:"type:date;not null"` // YYYY-MM-DD
+	Days        int       `gorm:"not null"`           // Days in the period, paused days excluded
+	PausedDays  int       `gorm:"not null;default:0"` // Days paused by the goal's pauses or the user's vacations
+	ExcuseDays  int       `gorm:"not null"`           // Days with an excuse, paused days excluded
+	SuccessRate float64   `gorm:"not null"`           // Share of days without an excuse, 0 to 1
+	CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
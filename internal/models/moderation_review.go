@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type ModerationReview struct {
+	ID            uuid.UUID      `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID        string         `gorm:"size:255;not null;index"`
+	Source        string         `gorm:"size:50;not null"` // "excuse" (also via sync), "import"
+	ExcuseEntryID *uuid.UUID     `gorm:"type:uuid;index"`
+	Text          string         `gorm:"type:text;not null"`
+	Reasons       pq.StringArray `gorm:"type:text[]"`
+	Status        string         `gorm:"size:50;not null;default:'pending';index"` // "pending", "approved", "removed"
+	ReviewedAt    *time.Time
+	CreatedAt     time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
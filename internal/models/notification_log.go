@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationLog records the daily reminder of a goal so it is never sent twice a day.
+type NotificationLog struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID    string    `gorm:"size:255;not null;index"`
+	GoalID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_goal_notification_date"`
+	Date      Date      `gorm:"type:date;not null;uniqueIndex:idx_goal_notification_date"` // YYYY-MM-DD in the user's local time
+	Status    string    `gorm:"size:50;not null;default:'pending'"`                        // "pending", "sent", "failed"
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
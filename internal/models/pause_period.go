@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PausePeriod is a range of days on which a goal is frozen: no reminders, no excuses, and the days do not count
+// in the goal's summary. Without GoalID it is a vacation covering every goal of the user.
+type PausePeriod struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID    string     `gorm:"size:255;not null;index"`
+	GoalID    *uuid.UUID `gorm:"type:uuid;index"`    // nil for a vacation
+	StartDate Date       `gorm:"type:date;not null"` // YYYY-MM-DD in the user's local time
+	EndDate   Date       `gorm:"type:date;not null"` // YYYY-MM-DD, included
+	CreatedAt time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
+}
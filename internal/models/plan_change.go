@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// PlanChange records a change of a user's plan, so that the data export can include the plan history.
+type PlanChange struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    string    `gorm:"size:255;not null;index"`
+	Plan      string    `gorm:"size:50;not null"` // The plan after the change
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
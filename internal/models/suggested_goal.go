@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// SuggestedGoal is an entry of the curated catalogue shown as chips on the goal creation screen.
+type SuggestedGoal struct {
+	ID                      string    `gorm:"primaryKey;size:255"` // "read-10-pages" etc
+	TitleJa                 string    `gorm:"size:255;not null"`
+	TitleEn                 string    `gorm:"size:255;not null"`
+	Category                string    `gorm:"size:50;not null;index"` // "health", "study", "lifestyle", "mind", "work"
+	Icon                    *string   `gorm:"size:64"`
+	DefaultNotificationTime *string   `gorm:"size:5"`                           // "HH:MM" format
+	RecommendedSchedule     string    `gorm:"size:50;not null;default:'daily'"` // "daily", "weekdays", "weekends"
+	IsActive                bool      `gorm:"default:true"`
+	Order                   int       `gorm:"default:0"`
+	CreatedAt               time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt               time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CreateSyncSequence creates the sequence that orders changes for delta sync. Run it before AutoMigrate.
+func CreateSyncSequence(db *gorm.DB) error {
+	return db.Exec("CREATE SEQUENCE IF NOT EXISTS sync_seq").Error
+}
+
+// LockUserChanges takes the per-user advisory lock, held until the transaction commits.
+// Every change of a user takes it when the row is written (see nextSyncSeq); take it earlier
+// to make a read and the write that depends on it atomic.
+func LockUserChanges(tx *gorm.DB, userID string) error {
+	return tx.Session(&gorm.Session{NewDB: true}).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", userID).Error
+}
+
+// nextSyncSeq returns the next sync sequence value for a change made by userID.
+// The per-user lock makes a user's changes become visible in sequence order,
+// so a sync cursor never skips a change committed late.
+func nextSyncSeq(tx *gorm.DB, userID string) (int64, error) {
+	if err := LockUserChanges(tx, userID); err != nil {
+		return 0, err
+	}
+	db := tx.Session(&gorm.Session{NewDB: true})
+	var seq int64
+	if err := db.Raw("SELECT nextval('sync_seq')").Scan(&seq).Error; err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// Tombstone records a deleted Goal or ExcuseEntry so that delta sync can tell clients to remove it.
+type Tombstone struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	UserID     string    `gorm:"size:255;not null;index:idx_tombstone_user_seq"`
+	EntityType string    `gorm:"size:50;not null"` // "goal", "excuse"
+	EntityID   uuid.UUID `gorm:"type:uuid;not null"`
+	SyncSeq    int64     `gorm:"not null;index:idx_tombstone_user_seq"`
+	DeletedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (t *Tombstone) BeforeCreate(tx *gorm.DB) error {
+	seq, err := nextSyncSeq(tx, t.UserID)
+	t.SyncSeq = seq
+	return err
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type Tone struct {
+	ID                 string    `gorm:"primaryKey;size:255"` // "casual", "surreal", etc
+	LabelJa            string    `gorm:"size:255;not null"`
+	LabelEn            string    `gorm:"size:255;not null"`
+	PromptInstructions string    `gorm:"type:text;not null"`
+	IsPremium          bool      `gorm:"default:false"`
+	IsActive           bool      `gorm:"default:true"`
+	Order              int       `gorm:"default:0"`
+	CreatedAt          time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
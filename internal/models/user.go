@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type User struct {
+	ID                           string     `gorm:"size:255;primaryKey"` // JWT sub
+	DisplayName                  string     `gorm:"size:50"`
+	Locale                       string     `gorm:"size:10;not null;default:'ja'"`         // ja / en
+	TimeZone                     string     `gorm:"size:64;not null;default:'Asia/Tokyo'"` // IANA name
+	WeekStartDay                 string     `gorm:"size:10;not null;default:'monday'"`     // monday / sunday
+	ReminderNotificationsEnabled bool       `gorm:"not null;default:true"`
+	NewsNotificationsEnabled     bool       `gorm:"not null;default:false"`
+	OnboardingCompleted          bool       `gorm:"not null;default:false"`
+	DeletionScheduledAt          *time.Time // Set while the account is waiting to be deleted
+	CreatedAt                    time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt                    time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
+}
@@ -27,8 +27,8 @@ func SeedExcuseEntries(db *gorm.DB) error {
 
 	var entries []models.ExcuseEntry
 
-	today := time.Now().Format("2006-01-02")
-	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	today := models.Date(time.Now().Format("2006-01-02"))
+	yesterday := models.Date(time.Now().AddDate(0, 0, -1).Format("2006-01-02"))
 
 	for i, goal := range goals {
 		// Create entry for today for some goals
@@ -25,6 +25,7 @@ func SeedExcuseTemplates(db *gorm.DB) error {
 			IsActive:  true,
 			IsPremium: false,
 			Tags:      pq.StringArray{"物理", "面白い"},
+			Tones:     pq.StringArray{"surreal"},
 		},
 		{
 			ID:        "cat-monitor",
@@ -33,6 +34,7 @@ func SeedExcuseTemplates(db *gorm.DB) error {
 			IsActive:  true,
 			IsPremium: false,
 			Tags:      pq.StringArray{"猫", "かわいい"},
+			Tones:     pq.StringArray{"casual"},
 		},
 		{
 			ID:        "coffee-spill",
@@ -41,6 +43,7 @@ func SeedExcuseTemplates(db *gorm.DB) error {
 			IsActive:  true,
 			IsPremium: false,
 			Tags:      pq.StringArray{"事故", "コーヒー"},
+			Tones:     pq.StringArray{"casual", "serious"},
 		},
 		{
 			ID:        "aliens",
@@ -49,6 +52,7 @@ func SeedExcuseTemplates(db *gorm.DB) error {
 			IsActive:  true,
 			IsPremium: true,
 			Tags:      pq.StringArray{"SF", "エイリアン"},
+			Tones:     pq.StringArray{"surreal"},
 		},
 	}
 
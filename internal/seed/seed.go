@@ -21,6 +21,10 @@ func Run(db *gorm.DB) error {
 			return fmt.Errorf("failed to seed excuse templates: %w", err)
 		}
 
+		if err := SeedSuggestedGoals(tx); err != nil {
+			return fmt.Errorf("failed to seed suggested goals: %w", err)
+		}
+
 		if err := SeedExcuseEntries(tx); err != nil {
 			return fmt.Errorf("failed to seed excuse entries: %w", err)
 		}
@@ -0,0 +1,35 @@
+package seed
+
+import (
+	"what-went-wrong-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+func SeedSuggestedGoals(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&models.SuggestedGoal{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return nil
+	}
+
+	str := func(s string) *string { return &s }
+
+	suggestions := []models.SuggestedGoal{
+		{ID: "read-10-pages", TitleJa: "本を10ページ読む", TitleEn: "Read 10 pages", Category: "study", Icon: str("📚"), DefaultNotificationTime: str("21:00"), RecommendedSchedule: "daily", IsActive: true, Order: 1},
+		{ID: "walk-10-minutes", TitleJa: "10分歩く", TitleEn: "Walk for 10 minutes", Category: "health", Icon: str("🚶"), DefaultNotificationTime: str("18:00"), RecommendedSchedule: "daily", IsActive: true, Order: 2},
+		{ID: "drink-water", TitleJa: "水をコップ1杯飲む", TitleEn: "Drink a glass of water", Category: "health", Icon: str("💧"), DefaultNotificationTime: str("08:00"), RecommendedSchedule: "daily", IsActive: true, Order: 3},
+		{ID: "stretch", TitleJa: "寝る前にストレッチ", TitleEn: "Stretch before bed", Category: "health", Icon: str("🧘"), DefaultNotificationTime: str("22:30"), RecommendedSchedule: "daily", IsActive: true, Order: 4},
+		{ID: "study-english", TitleJa: "英単語を5つ覚える", TitleEn: "Learn 5 new words", Category: "study", Icon: str("🔤"), DefaultNotificationTime: str("07:30"), RecommendedSchedule: "weekdays", IsActive: true, Order: 5},
+		{ID: "tidy-desk", TitleJa: "机の上を片付ける", TitleEn: "Tidy up the desk", Category: "lifestyle", Icon: str("🧹"), DefaultNotificationTime: str("19:00"), RecommendedSchedule: "weekdays", IsActive: true, Order: 6},
+		{ID: "journal", TitleJa: "3行日記を書く", TitleEn: "Write a 3-line journal", Category: "mind", Icon: str("📝"), DefaultNotificationTime: str("22:00"), RecommendedSchedule: "daily", IsActive: true, Order: 7},
+		{ID: "no-phone-in-bed", TitleJa: "布団でスマホを見ない", TitleEn: "No phone in bed", Category: "mind", Icon: str("📵"), DefaultNotificationTime: str("23:00"), RecommendedSchedule: "daily", IsActive: true, Order: 8},
+		{ID: "inbox-zero", TitleJa: "メールの受信箱を空にする", TitleEn: "Clear the inbox", Category: "work", Icon: str("📥"), DefaultNotificationTime: str("17:30"), RecommendedSchedule: "weekdays", IsActive: true, Order: 9},
+		{ID: "cook-at-home", TitleJa: "自炊する", TitleEn: "Cook at home", Category: "lifestyle", Icon: str("🍳"), RecommendedSchedule: "weekends", IsActive: true, Order: 10},
+	}
+
+	return db.Create(&suggestions).Error
+}
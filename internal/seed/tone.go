@@ -0,0 +1,56 @@
+package seed
+
+import (
+	"what-went-wrong-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SeedTones upserts the tone catalogue. Unlike the other seeds it also runs in
+// production, since POST /ai-excuse rejects unknown tones.
+func SeedTones(db *gorm.DB) error {
+	tones := []models.Tone{
+		{
+			ID:                 "casual",
+			LabelJa:            "ゆるい",
+			LabelEn:            "Casual",
+			PromptInstructions: "肩の力が抜けた、ゆるくて親しみやすい口調にしてください。",
+			IsPremium:          false,
+			IsActive:           true,
+			Order:              1,
+		},
+		{
+			ID:                 "serious",
+			LabelJa:            "真面目",
+			LabelEn:            "Serious",
+			PromptInstructions: "上司への報告のような、真面目で丁寧な口調にしてください。",
+			IsPremium:          false,
+			IsActive:           true,
+			Order:              2,
+		},
+		{
+			ID:                 "surreal",
+			LabelJa:            "シュール",
+			LabelEn:            "Surreal",
+			PromptInstructions: "物理法則や時空が歪むような、シュールで不条理な理由にしてください。",
+			IsPremium:          true,
+			IsActive:           true,
+			Order:              3,
+		},
+		{
+			ID:                 "philosophical",
+			LabelJa:            "哲学的",
+			LabelEn:            "Philosophical",
+			PromptInstructions: "存在や真理について語るような、哲学的で大げさな理由にしてください。",
+			IsPremium:          true,
+			IsActive:           true,
+			Order:              4,
+		},
+	}
+
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"label_ja", "label_en", "prompt_instructions", "is_premium", "is_active", "order"}),
+	}).Create(&tones).Error
+}
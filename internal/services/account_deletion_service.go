@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+	"what-went-wrong-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const defaultDeletionGraceDays = 30
+
+var ErrDeletionNotScheduled = errors.New("account deletion is not scheduled")
+
+// userOwnedModels lists every table keyed by user_id. A new table holding user data must be added here
+// so that it is removed with the account. The blobs of excuse attachments are deleted before the rows.
+var userOwnedModels = []any{
+	&models.ExcuseAttachment{},
+	&models.ExcuseRevision{},
+	&models.ExcuseEntry{},
+	&models.AiGeneration{},
+	&models.ModerationReview{},
+	&models.NotificationLog{},
+	&models.Device{},
+	&models.DataExport{},
+	&models.Tombstone{},
+	&models.GoalSummary{},
+	&models.PausePeriod{},
+	&models.Goal{},
+	&models.UserPlan{},
+	&models.PlanChange{},
+}
+
+// AccountDeletionService deletes accounts after a grace period during which the user can undo the request.
+type AccountDeletionService struct {
+	db          *gorm.DB
+	store       BlobStore
+	gracePeriod time.Duration
+	now         func() time.Time
+}
+
+func NewAccountDeletionService(db *gorm.DB, store BlobStore, gracePeriod time.Duration) *AccountDeletionService {
+	return &AccountDeletionService{db: db, store: store, gracePeriod: gracePeriod, now: time.Now}
+}
+
+// NewAccountDeletionServiceFromEnv reads the grace period in days from ACCOUNT_DELETION_GRACE_DAYS
+// (default 30, 0 deletes immediately).
+func NewAccountDeletionServiceFromEnv(db *gorm.DB, store BlobStore) (*AccountDeletionService, error) {
+	days := defaultDeletionGraceDays
+	if v := os.Getenv("ACCOUNT_DELETION_GRACE_DAYS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid ACCOUNT_DELETION_GRACE_DAYS: %q", v)
+		}
+		days = n
+	}
+	return NewAccountDeletionService(db, store, time.Duration(days)*24*time.Hour), nil
+}
+
+// RequestDeletion schedules the account for deletion. With no grace period it is deleted right away
+// and nil is returned.
+func (s *AccountDeletionService) RequestDeletion(userID string) (*time.Time, error) {
+	if s.gracePeriod <= 0 {
+		return nil, s.DeleteAccount(userID)
+	}
+
+	scheduledAt := s.now().Add(s.gracePeriod)
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Keep the original date when the request is repeated
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND deletion_scheduled_at IS NULL", userID).
+			Update("deletion_scheduled_at", scheduledAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			var user models.User
+			if err := tx.First(&user, "id = ?", userID).Error; err != nil {
+				return err
+			}
+			scheduledAt = *user.DeletionScheduledAt
+			return nil
+		}
+		return writeAuditLog(tx, "account_deletion_requested", userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &scheduledAt, nil
+}
+
+// CancelDeletion undoes RequestDeletion while the grace period is running.
+func (s *AccountDeletionService) CancelDeletion(userID string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).
+			Where("id = ? AND deletion_scheduled_at IS NOT NULL", userID).
+			Update("deletion_scheduled_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrDeletionNotScheduled
+		}
+		return writeAuditLog(tx, "account_deletion_cancelled", userID)
+	})
+}
+
+// DeleteAccount removes the user and every row owned by the user in one transaction, after the blobs of the
+// user's attachments. When it fails the account is left in place, so that the next purge retries it.
+func (s *AccountDeletionService) DeleteAccount(userID string) error {
+	var attachments []models.ExcuseAttachment
+	if err := s.db.Where("user_id = ?", userID).Find(&attachments).Error; err != nil {
+		return err
+	}
+	for _, a := range attachments {
+		if err := DeleteAttachmentBlobs(context.Background(), s.store, a); err != nil {
+			return err
+		}
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range userOwnedModels {
+			// Unscoped so that goals and excuses in the trash are removed too
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("id = ?", userID).Delete(&models.User{}).Error; err != nil {
+			return err
+		}
+		return writeAuditLog(tx, "account_deleted", userID)
+	})
+}
+
+// PurgeDue deletes the accounts whose grace period has ended.
+func (s *AccountDeletionService) PurgeDue() error {
+	var userIDs []string
+	if err := s.db.Model(&models.User{}).Where("deletion_scheduled_at <= ?", s.now()).Pluck("id", &userIDs).Error; err != nil {
+		return err
+	}
+	for _, userID := range userIDs {
+		if err := s.DeleteAccount(userID); err != nil {
+			log.Printf("Failed to delete account %s: %v", subjectHash(userID), err)
+		}
+	}
+	return nil
+}
+
+// Run purges due accounts every hour until ctx is cancelled.
+func (s *AccountDeletionService) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.PurgeDue(); err != nil {
+				log.Printf("Account purge failed: %v", err)
+			}
+		}
+	}
+}
+
+func writeAuditLog(tx *gorm.DB, action string, userID string) error {
+	return tx.Create(&models.AuditLog{Action: action, SubjectHash: subjectHash(userID)}).Error
+}
+
+func subjectHash(userID string) string {
+	sum := sha256.Sum256([]byte(userID))
+	return hex.EncodeToString(sum[:])
+}
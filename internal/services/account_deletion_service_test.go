@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteAccount(t *testing.T) {
+	db, cleanup := testdb.Setup(t)
+	defer cleanup()
+	store, err := NewLocalBlobStore(t.TempDir())
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	userID := "auth0|test"
+	db.Create(&models.User{ID: userID})
+	goal := models.Goal{UserID: userID, Title: "Run"}
+	db.Create(&goal)
+	excuse := models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-01", ExcuseText: "雨だった"}
+	db.Create(&excuse)
+	attachment := models.ExcuseAttachment{
+		UserID:       userID,
+		ExcuseID:     excuse.ID,
+		ContentType:  "image/png",
+		BlobKey:      "attachments/" + uuid.NewString() + "/original",
+		ThumbnailKey: "attachments/" + uuid.NewString() + "/thumbnail",
+	}
+	db.Create(&attachment)
+	require.NoError(t, store.Put(ctx, attachment.BlobKey, []byte("image"), "image/png"))
+	require.NoError(t, store.Put(ctx, attachment.ThumbnailKey, []byte("thumbnail"), "image/png"))
+
+	require.NoError(t, NewAccountDeletionService(db, store, 0).DeleteAccount(userID))
+
+	var count int64
+	db.Model(&models.ExcuseAttachment{}).Where("user_id = ?", userID).Count(&count)
+	assert.Equal(t, int64(0), count)
+	db.Unscoped().Model(&models.ExcuseEntry{}).Where("user_id = ?", userID).Count(&count)
+	assert.Equal(t, int64(0), count)
+	_, err = store.Get(ctx, attachment.BlobKey)
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+	_, err = store.Get(ctx, attachment.ThumbnailKey)
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+}
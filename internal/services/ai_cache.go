@@ -0,0 +1,100 @@
+package services
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type aiCacheEntry struct {
+	key       string
+	value     GeneratedExcuses
+	expiresAt time.Time
+}
+
+// CachedAIService keeps recent generations in an LRU cache with TTL,
+// keyed by goal, tone and context, so identical requests don't hit the model.
+// Generations picked from templates are not cached: they stand in while the LLMs are down,
+// and caching them would keep serving templates after the LLMs recover.
+type CachedAIService struct {
+	service  AIService
+	capacity int
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+	now     func() time.Time
+}
+
+func NewCachedAIService(service AIService, capacity int, ttl time.Duration) *CachedAIService {
+	return &CachedAIService{
+		service:  service,
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (s *CachedAIService) GenerateExcuse(req ExcuseGenerationRequest) (*GeneratedExcuses, error) {
+	key := req.GoalID.String() + "\x00" + req.Tone + "\x00" + req.Context
+
+	if cached, ok := s.get(key); ok {
+		return cached, nil
+	}
+
+	generated, err := s.service.GenerateExcuse(req)
+	if err != nil {
+		return nil, err
+	}
+	if generated.Model != templateModel {
+		s.put(key, generated)
+	}
+	return generated, nil
+}
+
+func (s *CachedAIService) get(key string) (*GeneratedExcuses, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*aiCacheEntry)
+	if s.now().After(entry.expiresAt) {
+		s.order.Remove(elem)
+		delete(s.entries, key)
+		return nil, false
+	}
+	s.order.MoveToFront(elem)
+
+	value := entry.value
+	value.Candidates = append([]string(nil), entry.value.Candidates...)
+	return &value, true
+}
+
+func (s *CachedAIService) put(key string, generated *GeneratedExcuses) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := &aiCacheEntry{
+		key:       key,
+		value:     GeneratedExcuses{Candidates: append([]string(nil), generated.Candidates...), Model: generated.Model},
+		expiresAt: s.now().Add(s.ttl),
+	}
+	if elem, ok := s.entries[key]; ok {
+		elem.Value = entry
+		s.order.MoveToFront(elem)
+		return
+	}
+
+	s.entries[key] = s.order.PushFront(entry)
+	for s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*aiCacheEntry).key)
+	}
+}
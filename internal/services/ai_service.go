@@ -5,7 +5,7 @@ import (
 )
 
 type AIService interface {
-	GenerateExcuse(tone string, context string) ([]string, error)
+	GenerateExcuse(req ExcuseGenerationRequest) (*GeneratedExcuses, error)
 }
 
 type MockAIService struct{}
@@ -14,10 +14,11 @@ func NewMockAIService() *MockAIService {
 	return &MockAIService{}
 }
 
-func (s *MockAIService) GenerateExcuse(tone string, context string) ([]string, error) {
+func (s *MockAIService) GenerateExcuse(req ExcuseGenerationRequest) (*GeneratedExcuses, error) {
 	// Mock implementation returning dummy excuses based on tone
+	context := req.Context
 	var candidates []string
-	switch tone {
+	switch req.Tone {
 	case "surreal":
 		candidates = []string{
 			fmt.Sprintf("重力が強すぎて、%s ができませんでした。", context),
@@ -34,5 +35,5 @@ func (s *MockAIService) GenerateExcuse(tone string, context string) ([]string, e
 			fmt.Sprintf("今日は %s の日ではありませんでした。", context),
 		}
 	}
-	return candidates, nil
+	return &GeneratedExcuses{Candidates: candidates, Model: "mock"}, nil
 }
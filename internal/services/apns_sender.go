@@ -0,0 +1,129 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	apnsProductionURL  = "https://api.push.apple.com"
+	apnsDevelopmentURL = "https://api.sandbox.push.apple.com"
+	// APNs rejects provider tokens older than an hour
+	apnsTokenLifetime = 50 * time.Minute
+)
+
+// APNsSender sends notifications with the APNs HTTP/2 provider API and token based authentication.
+type APNsSender struct {
+	baseURL string
+	key     *ecdsa.PrivateKey
+	keyID   string
+	teamID  string
+	topic   string // App bundle ID
+	client  *http.Client
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+// NewAPNsSender creates a sender from the .p8 signing key downloaded from the Apple developer portal.
+func NewAPNsSender(p8Key []byte, keyID string, teamID string, topic string, production bool) (*APNsSender, error) {
+	key, err := jwt.ParseECPrivateKeyFromPEM(p8Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse APNs key: %w", err)
+	}
+
+	baseURL := apnsDevelopmentURL
+	if production {
+		baseURL = apnsProductionURL
+	}
+
+	return &APNsSender{
+		baseURL: baseURL,
+		key:     key,
+		keyID:   keyID,
+		teamID:  teamID,
+		topic:   topic,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (s *APNsSender) providerToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Since(s.issuedAt) < apnsTokenLifetime {
+		return s.token, nil
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": s.teamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = s.keyID
+
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		return "", err
+	}
+	s.token = signed
+	s.issuedAt = now
+	return signed, nil
+}
+
+func (s *APNsSender) Send(ctx context.Context, deviceToken string, n Notification) error {
+	payload, err := json.Marshal(map[string]any{
+		"aps": map[string]any{
+			"alert": map[string]string{
+				"title": n.Title,
+				"body":  n.Body,
+			},
+			"sound": "default",
+		},
+		"goalId": n.GoalID.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	providerToken, err := s.providerToken()
+	if err != nil {
+		return fmt.Errorf("failed to sign APNs provider token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/3/device/"+deviceToken, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("authorization", "bearer "+providerToken)
+	req.Header.Set("apns-topic", s.topic)
+	req.Header.Set("apns-push-type", "alert")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("APNs request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var apnsErr struct {
+		Reason string `json:"reason"`
+	}
+	json.NewDecoder(resp.Body).Decode(&apnsErr)
+	if resp.StatusCode == http.StatusGone || apnsErr.Reason == "BadDeviceToken" || apnsErr.Reason == "Unregistered" {
+		return fmt.Errorf("%w: %s", ErrInvalidPushToken, apnsErr.Reason)
+	}
+	return fmt.Errorf("APNs returned status %d: %s", resp.StatusCode, apnsErr.Reason)
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+	"what-went-wrong-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentCleaner removes the photos of excuses that no longer exist: purged from the trash, replaced by a new
+// excuse of the same day, or left behind by an account deletion that failed half way. Photos of excuses in the trash are kept so they come back
+// on restore.
+type AttachmentCleaner struct {
+	db    *gorm.DB
+	store BlobStore
+}
+
+func NewAttachmentCleaner(db *gorm.DB, store BlobStore) *AttachmentCleaner {
+	return &AttachmentCleaner{db: db, store: store}
+}
+
+// DeleteOrphans deletes the blobs and rows of attachments whose excuse is gone.
+// A row is removed only after its blobs, so a failure is retried on the next run.
+func (s *AttachmentCleaner) DeleteOrphans(ctx context.Context) error {
+	const batchSize = 500
+	for {
+		var attachments []models.ExcuseAttachment
+		err := s.db.
+			Where("NOT EXISTS (SELECT 1 FROM excuse_entries WHERE excuse_entries.id = excuse_attachments.excuse_id)").
+			Limit(batchSize).
+			Find(&attachments).Error
+		if err != nil {
+			return err
+		}
+
+		for _, a := range attachments {
+			if err := DeleteAttachmentBlobs(ctx, s.store, a); err != nil {
+				return err
+			}
+			if err := s.db.Delete(&a).Error; err != nil {
+				return err
+			}
+		}
+		if len(attachments) < batchSize {
+			return nil
+		}
+	}
+}
+
+// DeleteAttachmentBlobs removes the image and the thumbnail of the attachment from the store.
+func DeleteAttachmentBlobs(ctx context.Context, store BlobStore, a models.ExcuseAttachment) error {
+	if err := store.Delete(ctx, a.BlobKey); err != nil {
+		return err
+	}
+	return store.Delete(ctx, a.ThumbnailKey)
+}
+
+// Run deletes orphaned attachments every hour until ctx is cancelled.
+func (s *AttachmentCleaner) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.DeleteOrphans(ctx); err != nil {
+				log.Printf("Attachment cleanup failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrBlobNotFound is returned by a BlobStore when no object is stored under the key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps binary objects, such as the photos attached to excuses, under slash-separated keys.
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the object. Deleting a key that does not exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// NewBlobStoreFromEnv returns an S3BlobStore when BLOB_STORE is "s3" (configured by S3_ENDPOINT, S3_REGION, S3_BUCKET,
+// S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY), otherwise a LocalBlobStore in BLOB_STORE_DIR (default "data/blobs").
+func NewBlobStoreFromEnv() (BlobStore, error) {
+	switch os.Getenv("BLOB_STORE") {
+	case "s3":
+		return NewS3BlobStore(
+			os.Getenv("S3_ENDPOINT"),
+			os.Getenv("S3_REGION"),
+			os.Getenv("S3_BUCKET"),
+			os.Getenv("S3_ACCESS_KEY_ID"),
+			os.Getenv("S3_SECRET_ACCESS_KEY"),
+		)
+	case "", "local":
+		dir := os.Getenv("BLOB_STORE_DIR")
+		if dir == "" {
+			dir = "data/blobs"
+		}
+		return NewLocalBlobStore(dir)
+	default:
+		return nil, fmt.Errorf("invalid BLOB_STORE: %q", os.Getenv("BLOB_STORE"))
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testBlobStore runs the behavior every BlobStore must share.
+func testBlobStore(t *testing.T, store BlobStore) {
+	ctx := context.Background()
+
+	_, err := store.Get(ctx, "attachments/a/original")
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+
+	assert.NoError(t, store.Put(ctx, "attachments/a/original", []byte("first"), "image/png"))
+	assert.NoError(t, store.Put(ctx, "attachments/a/original", []byte("second"), "image/png"))
+	data, err := store.Get(ctx, "attachments/a/original")
+	assert.NoError(t, err)
+	assert.Equal(t, "second", string(data))
+
+	assert.NoError(t, store.Delete(ctx, "attachments/a/original"))
+	_, err = store.Get(ctx, "attachments/a/original")
+	assert.ErrorIs(t, err, ErrBlobNotFound)
+	assert.NoError(t, store.Delete(ctx, "attachments/a/original"), "deleting a missing key is not an error")
+}
+
+func TestLocalBlobStore(t *testing.T) {
+	store, err := NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+	testBlobStore(t, store)
+
+	t.Run("EscapingKey", func(t *testing.T) {
+		for _, key := range []string{"", "../outside", "/etc/passwd", "a/../../outside"} {
+			assert.Error(t, store.Put(context.Background(), key, []byte("x"), "text/plain"), key)
+		}
+	})
+}
+
+func TestS3BlobStore(t *testing.T) {
+	// A stand-in for S3 keeping objects in memory and checking that requests are signed
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	var gotContentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/20261019/auto/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=") ||
+			r.Header.Get("X-Amz-Date") != "20261019T120000Z" ||
+			r.Header.Get("X-Amz-Content-Sha256") != sha256Hex(body) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<Error><Code>SignatureDoesNotMatch</Code></Error>"))
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			objects[r.URL.Path] = body
+			gotContentType = r.Header.Get("Content-Type")
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(data)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewS3BlobStore(server.URL, "auto", "excuses", "AKID", "secret")
+	assert.NoError(t, err)
+	store.now = func() time.Time { return time.Date(2026, 10, 19, 21, 0, 0, 0, time.FixedZone("JST", 9*60*60)) }
+	testBlobStore(t, store)
+	assert.Equal(t, "image/png", gotContentType)
+
+	t.Run("PathStyle", func(t *testing.T) {
+		assert.NoError(t, store.Put(context.Background(), "attachments/b/thumbnail.jpg", []byte("x"), "image/jpeg"))
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Contains(t, objects, "/excuses/attachments/b/thumbnail.jpg")
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		bad, _ := NewS3BlobStore(server.URL, "auto", "excuses", "OTHER", "secret")
+		err := bad.Put(context.Background(), "attachments/c/original", []byte("x"), "image/png")
+		assert.ErrorContains(t, err, "403")
+	})
+
+	t.Run("MissingConfig", func(t *testing.T) {
+		_, err := NewS3BlobStore(server.URL, "auto", "", "AKID", "secret")
+		assert.Error(t, err)
+	})
+}
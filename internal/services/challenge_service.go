@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+	"what-went-wrong-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ChallengeService closes goals whose end date has passed in the user's time zone:
+// it writes their GoalSummary and archives them.
+type ChallengeService struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewChallengeService(db *gorm.DB) *ChallengeService {
+	return &ChallengeService{db: db, now: time.Now}
+}
+
+// CloseEnded summarizes and archives every goal that ended before the user's today and has no summary yet.
+// A goal unarchived after its summary was written is left alone.
+func (s *ChallengeService) CloseEnded() error {
+	var timeZones []string
+	err := s.db.Model(&models.Goal{}).
+		Select("DISTINCT COALESCE(users.time_zone, ?)", DefaultTimeZone).
+		Joins("LEFT JOIN users ON users.id = goals.user_id").
+		Where("goals.end_date IS NOT NULL").
+		Scan(&timeZones).Error
+	if err != nil {
+		return err
+	}
+
+	for _, timeZone := range timeZones {
+		loc := LoadLocation(timeZone)
+		today := s.now().In(loc).Format("2006-01-02")
+
+		var goals []models.Goal
+		err := s.db.
+			Select("goals.*").
+			Joins("LEFT JOIN users ON users.id = goals.user_id").
+			Where("COALESCE(users.time_zone, ?) = ?", DefaultTimeZone, timeZone).
+			Where("goals.end_date < ?", today).
+			Where("NOT EXISTS (SELECT 1 FROM goal_summaries WHERE goal_summaries.goal_id = goals.id)").
+			Find(&goals).Error
+		if err != nil {
+			return err
+		}
+		for _, goal := range goals {
+			if err := s.close(goal, loc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *ChallengeService) close(goal models.Goal, loc *time.Location) error {
+	start := models.Date(goal.CreatedAt.In(loc).Format("2006-01-02"))
+	if goal.StartDate != nil {
+		start = *goal.StartDate
+	}
+	end := *goal.EndDate
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var excuseDates []models.Date
+		err := tx.Model(&models.ExcuseEntry{}).
+			Where("goal_id = ? AND date BETWEEN ? AND ?", goal.ID, start, end).
+			Distinct().
+			Pluck("date", &excuseDates).Error
+		if err != nil {
+			return err
+		}
+		var pauses []models.PausePeriod
+		err = tx.Where("user_id = ? AND (goal_id = ? OR goal_id IS NULL)", goal.UserID, goal.ID).
+			Where("start_date <= ? AND end_date >= ?", end, start).
+			Find(&pauses).Error
+		if err != nil {
+			return err
+		}
+
+		summary := summarizePeriod(start, end, pauses, excuseDates)
+		summary.GoalID = goal.ID
+		summary.UserID = goal.UserID
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&summary).Error; err != nil {
+			return err
+		}
+
+		if goal.ArchivedAt != nil {
+			return nil
+		}
+		now := s.now()
+		goal.ArchivedAt = &now
+		goal.UpdatedAt = now
+		return tx.Save(&goal).Error
+	})
+}
+
+// summarizePeriod counts the days from start to end (both included) and the days with an excuse among them.
+// Days covered by a pause are counted apart and left out of both.
+func summarizePeriod(start, end models.Date, pauses []models.PausePeriod, excuseDates []models.Date) models.GoalSummary {
+	summary := models.GoalSummary{StartDate: start, EndDate: end}
+	from, err := start.Time()
+	if err != nil {
+		return summary
+	}
+	to, err := end.Time()
+	if err != nil {
+		return summary
+	}
+
+	excused := make(map[models.Date]bool, len(excuseDates))
+	for _, d := range excuseDates {
+		excused[d] = true
+	}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := models.Date(day.Format("2006-01-02"))
+		paused := false
+		for _, p := range pauses {
+			if p.StartDate <= date && date <= p.EndDate {
+				paused = true
+				break
+			}
+		}
+		switch {
+		case paused:
+			summary.PausedDays++
+		case excused[date]:
+			summary.Days++
+			summary.ExcuseDays++
+		default:
+			summary.Days++
+		}
+	}
+	if summary.Days > 0 {
+		summary.SuccessRate = float64(summary.Days-summary.ExcuseDays) / float64(summary.Days)
+	}
+	return summary
+}
+
+// Run closes ended goals every hour until ctx is cancelled.
+func (s *ChallengeService) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.CloseEnded(); err != nil {
+				log.Printf("Closing ended goals failed: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/testdb"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizePeriod(t *testing.T) {
+	t.Run("Days", func(t *testing.T) {
+		assert.Equal(t, 30, summarizePeriod("2025-01-01", "2025-01-30", nil, nil).Days)
+		assert.Equal(t, 1, summarizePeriod("2025-01-01", "2025-01-01", nil, nil).Days)
+		assert.Equal(t, 29, summarizePeriod("2024-02-01", "2024-02-29", nil, nil).Days)
+		assert.Equal(t, 0, summarizePeriod("2025-01-02", "2025-01-01", nil, nil).Days)
+	})
+
+	t.Run("ExcusesAndPauses", func(t *testing.T) {
+		pauses := []models.PausePeriod{
+			{StartDate: "2024-12-30", EndDate: "2025-01-02"},
+			{StartDate: "2025-01-02", EndDate: "2025-01-03"},
+		}
+		excuses := []models.Date{"2025-01-02", "2025-01-05", "2025-01-06"}
+
+		summary := summarizePeriod("2025-01-01", "2025-01-10", pauses, excuses)
+
+		assert.Equal(t, 3, summary.PausedDays)
+		assert.Equal(t, 7, summary.Days)
+		assert.Equal(t, 2, summary.ExcuseDays) // The excuse on a paused day does not count
+		assert.InDelta(t, 5.0/7.0, summary.SuccessRate, 1e-9)
+	})
+}
+
+func TestCloseEnded(t *testing.T) {
+	db, cleanup := testdb.Setup(t)
+	defer cleanup()
+
+	userID := "auth0|test"
+	start, end := models.Date("2025-01-01"), models.Date("2025-01-10")
+	goal := models.Goal{UserID: userID, Title: "10-day challenge", StartDate: &start, EndDate: &end}
+	db.Create(&goal)
+	for _, date := range []models.Date{"2025-01-01", "2025-01-04", "2025-01-10", "2025-01-11"} {
+		db.Create(&models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: date, ExcuseText: "Excuse"})
+	}
+	db.Create(&models.PausePeriod{UserID: userID, GoalID: &goal.ID, StartDate: "2025-01-03", EndDate: "2025-01-04"})
+
+	service := NewChallengeService(db)
+	service.now = func() time.Time { return time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC) }
+	require.NoError(t, service.CloseEnded())
+
+	var summary models.GoalSummary
+	require.NoError(t, db.First(&summary, "goal_id = ?", goal.ID).Error)
+	assert.Equal(t, start, summary.StartDate)
+	assert.Equal(t, end, summary.EndDate)
+	assert.Equal(t, 8, summary.Days)
+	assert.Equal(t, 2, summary.PausedDays)
+	// The excuse on the paused day and the one after the end do not count
+	assert.Equal(t, 2, summary.ExcuseDays)
+	assert.InDelta(t, 6.0/8.0, summary.SuccessRate, 1e-9)
+
+	var closed models.Goal
+	db.First(&closed, "id = ?", goal.ID)
+	assert.NotNil(t, closed.ArchivedAt)
+	assert.Equal(t, end, *closed.EndDate)
+}
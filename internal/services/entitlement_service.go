@@ -49,14 +49,24 @@ func (s *EntitlementService) UpdatePlan(userID string, planName string) (*models
 					Plan:      planName,
 					UpdatedAt: time.Now(),
 				}
-				return tx.Create(&plan).Error
+				if err := tx.Create(&plan).Error; err != nil {
+					return err
+				}
+				return tx.Create(&models.PlanChange{UserID: userID, Plan: planName}).Error
 			}
 			return err
 		}
 
+		changed := plan.Plan != planName
 		plan.Plan = planName
 		plan.UpdatedAt = time.Now()
-		return tx.Save(&plan).Error
+		if err := tx.Save(&plan).Error; err != nil {
+			return err
+		}
+		if !changed {
+			return nil
+		}
+		return tx.Create(&models.PlanChange{UserID: userID, Plan: planName}).Error
 	})
 
 	if err != nil {
@@ -72,6 +82,8 @@ func (s *EntitlementService) GetEntitlements(planName string) Entitlements {
 			LogRetentionDays:       nil, // Unlimited
 			CanUseAiExcuse:         true,
 			CanUsePremiumTemplates: true,
+			CanUsePremiumPalettes:  true,
+			MaxStorageBytes:        2 << 30, // 2 GiB
 		}
 	}
 
@@ -82,6 +94,8 @@ func (s *EntitlementService) GetEntitlements(planName string) Entitlements {
 		LogRetentionDays:       &retention,
 		CanUseAiExcuse:         false,
 		CanUsePremiumTemplates: false,
+		CanUsePremiumPalettes:  false,
+		MaxStorageBytes:        50 << 20, // 50 MiB
 	}
 }
 
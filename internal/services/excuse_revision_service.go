@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+	"what-went-wrong-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ExcuseRevisionService deletes the revisions of excuses that the user's plan no longer keeps.
+type ExcuseRevisionService struct {
+	db           *gorm.DB
+	entitlements *EntitlementService
+	now          func() time.Time
+}
+
+func NewExcuseRevisionService(db *gorm.DB) *ExcuseRevisionService {
+	return &ExcuseRevisionService{db: db, entitlements: NewEntitlementService(db), now: time.Now}
+}
+
+// PruneExpired deletes the revisions replaced before the log retention of the user's plan,
+// and those whose excuse is gone (purged from the trash, replaced by a new excuse, or deleted with the account).
+func (s *ExcuseRevisionService) PruneExpired() error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		premiumUsers := tx.Model(&models.UserPlan{}).Select("user_id").Where("plan = ?", "premium")
+		if days := s.entitlements.GetEntitlements("premium").LogRetentionDays; days != nil {
+			err := tx.Where("user_id IN (?) AND created_at < ?", premiumUsers, s.now().AddDate(0, 0, -*days)).
+				Delete(&models.ExcuseRevision{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		// Users without a plan are on the free plan
+		if days := s.entitlements.GetEntitlements("free").LogRetentionDays; days != nil {
+			err := tx.Where("user_id NOT IN (?) AND created_at < ?", premiumUsers, s.now().AddDate(0, 0, -*days)).
+				Delete(&models.ExcuseRevision{}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.
+			Where("NOT EXISTS (SELECT 1 FROM excuse_entries WHERE excuse_entries.id = excuse_revisions.excuse_id)").
+			Delete(&models.ExcuseRevision{}).Error
+	})
+}
+
+// Run prunes revisions every hour until ctx is cancelled.
+func (s *ExcuseRevisionService) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.PruneExpired(); err != nil {
+				log.Printf("Excuse revision pruning failed: %v", err)
+			}
+		}
+	}
+}
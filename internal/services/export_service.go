@@ -0,0 +1,368 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"time"
+	"what-went-wrong-api/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	exportPollInterval = 10 * time.Second
+	// Archives can be downloaded for this long after they are built
+	exportRetention = 7 * 24 * time.Hour
+	// Exports left in "processing" longer than this (e.g. by a crashed instance) are picked up again
+	exportStaleAfter = 10 * time.Minute
+)
+
+type exportProfile struct {
+	ID                           string    `json:"id"`
+	DisplayName                  string    `json:"displayName"`
+	Locale                       string    `json:"locale"`
+	TimeZone                     string    `json:"timeZone"`
+	WeekStartDay                 string    `json:"weekStartDay"`
+	ReminderNotificationsEnabled bool      `json:"reminderNotificationsEnabled"`
+	NewsNotificationsEnabled     bool      `json:"newsNotificationsEnabled"`
+	OnboardingCompleted          bool      `json:"onboardingCompleted"`
+	CreatedAt                    time.Time `json:"createdAt"`
+}
+
+type exportPlan struct {
+	Plan      string     `json:"plan"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+type exportPlanChange struct {
+	Plan      string    `json:"plan"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+type exportGoal struct {
+	ID                  uuid.UUID  `json:"id"`
+	Title               string     `json:"title"`
+	NotificationTime    *string    `json:"notificationTime,omitempty"`
+	NotificationEnabled bool       `json:"notificationEnabled"`
+	Icon                *string    `json:"icon,omitempty"`
+	Color               *string    `json:"color,omitempty"`
+	CoverImageURL       *string    `json:"coverImageUrl,omitempty"`
+	Order               int        `json:"order"`
+	StartDate           *string    `json:"startDate,omitempty"`
+	EndDate             *string    `json:"endDate,omitempty"`
+	MaxExcusesPerDay    int        `json:"maxExcusesPerDay"`
+	ArchivedAt          *time.Time `json:"archivedAt,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+type exportExcuse struct {
+	ID             uuid.UUID  `json:"id"`
+	GoalID         uuid.UUID  `json:"goalId"`
+	GoalTitle      string     `json:"goalTitle"`
+	Date           string     `json:"date"`
+	ExcuseText     string     `json:"excuseText"`
+	TemplateID     *string    `json:"templateId,omitempty"`
+	TemplateText   *string    `json:"templateText,omitempty"`
+	Mood           *int       `json:"mood,omitempty"`
+	Tags           []string   `json:"tags,omitempty"`
+	Note           *string    `json:"note,omitempty"`
+	AiGenerationID *uuid.UUID `json:"aiGenerationId,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+type exportAiGeneration struct {
+	ID         uuid.UUID `json:"id"`
+	GoalID     uuid.UUID `json:"goalId"`
+	Date       string    `json:"date"`
+	Tone       string    `json:"tone"`
+	Context    string    `json:"context"`
+	Candidates []string  `json:"candidates"`
+	Model      string    `json:"model"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type exportData struct {
+	Profile       exportProfile
+	Plan          exportPlan
+	PlanHistory   []exportPlanChange
+	Goals         []exportGoal
+	Excuses       []exportExcuse
+	AiGenerations []exportAiGeneration
+}
+
+// ExportService builds the data export archives requested through POST /me/exports.
+type ExportService struct {
+	db *gorm.DB
+}
+
+func NewExportService(db *gorm.DB) *ExportService {
+	return &ExportService{db: db}
+}
+
+// Run builds pending exports and removes expired archives until ctx is cancelled.
+func (s *ExportService) Run(ctx context.Context) {
+	ticker := time.NewTicker(exportPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.ProcessPending(); err != nil {
+				log.Printf("Export processing failed: %v", err)
+			}
+			if err := s.RemoveExpired(); err != nil {
+				log.Printf("Failed to remove expired exports: %v", err)
+			}
+		}
+	}
+}
+
+// RemoveExpired deletes archives past their expiry. Failed exports are kept as long as an archive would be,
+// so that polling clients see the failure.
+func (s *ExportService) RemoveExpired() error {
+	now := time.Now()
+	return s.db.Where("expires_at < ? OR (status = ? AND updated_at < ?)", now, "failed", now.Add(-exportRetention)).
+		Delete(&models.DataExport{}).Error
+}
+
+// ProcessPending builds every pending export.
+func (s *ExportService) ProcessPending() error {
+	var exports []models.DataExport
+	err := s.db.Select("id", "user_id").
+		Where("status = ? OR (status = ? AND updated_at < ?)", "pending", "processing", time.Now().Add(-exportStaleAfter)).
+		Order("created_at asc").
+		Find(&exports).Error
+	if err != nil {
+		return err
+	}
+
+	for _, export := range exports {
+		// Claim the export so that another instance does not build it too
+		result := s.db.Model(&models.DataExport{}).
+			Where("id = ? AND (status = ? OR (status = ? AND updated_at < ?))", export.ID, "pending", "processing", time.Now().Add(-exportStaleAfter)).
+			Updates(map[string]any{"status": "processing", "updated_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		archive, err := s.BuildArchive(export.UserID)
+		if err != nil {
+			log.Printf("Failed to build export %s: %v", export.ID, err)
+			s.db.Model(&models.DataExport{}).Where("id = ?", export.ID).
+				Updates(map[string]any{"status": "failed", "updated_at": time.Now()})
+			continue
+		}
+
+		now := time.Now()
+		err = s.db.Model(&models.DataExport{}).Where("id = ?", export.ID).Updates(map[string]any{
+			"status":       "completed",
+			"archive":      archive,
+			"size":         len(archive),
+			"completed_at": now,
+			"expires_at":   now.Add(exportRetention),
+			"updated_at":   now,
+		}).Error
+		if err != nil {
+			log.Printf("Failed to save export %s: %v", export.ID, err)
+		}
+	}
+	return nil
+}
+
+// BuildArchive returns a ZIP of the user's profile, plan and its history, goals, excuses and AI generations.
+func (s *ExportService) BuildArchive(userID string) ([]byte, error) {
+	data, err := s.collect(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeExportArchive(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *ExportService) collect(userID string) (exportData, error) {
+	var data exportData
+
+	var user models.User
+	if err := s.db.First(&user, "id = ?", userID).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return data, err
+	}
+	data.Profile = exportProfile{
+		ID:                           userID,
+		DisplayName:                  user.DisplayName,
+		Locale:                       user.Locale,
+		TimeZone:                     user.TimeZone,
+		WeekStartDay:                 user.WeekStartDay,
+		ReminderNotificationsEnabled: user.ReminderNotificationsEnabled,
+		NewsNotificationsEnabled:     user.NewsNotificationsEnabled,
+		OnboardingCompleted:          user.OnboardingCompleted,
+		CreatedAt:                    user.CreatedAt,
+	}
+
+	var plan models.UserPlan
+	if err := s.db.First(&plan, "user_id = ?", userID).Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return data, err
+	}
+	data.Plan = exportPlan{Plan: plan.Plan, ExpiresAt: plan.ExpiresAt, UpdatedAt: plan.UpdatedAt}
+	if data.Plan.Plan == "" {
+		data.Plan.Plan = "free"
+	}
+
+	var changes []models.PlanChange
+	if err := s.db.Where("user_id = ?", userID).Order("created_at asc, id asc").Find(&changes).Error; err != nil {
+		return data, err
+	}
+	data.PlanHistory = make([]exportPlanChange, len(changes))
+	for i, c := range changes {
+		data.PlanHistory[i] = exportPlanChange{Plan: c.Plan, ChangedAt: c.CreatedAt}
+	}
+
+	var goals []models.Goal
+	if err := s.db.Where("user_id = ?", userID).Order("\"order\" asc, created_at asc").Find(&goals).Error; err != nil {
+		return data, err
+	}
+	data.Goals = make([]exportGoal, len(goals))
+	for i, g := range goals {
+		data.Goals[i] = exportGoal{
+			ID:                  g.ID,
+			Title:               g.Title,
+			NotificationTime:    g.NotificationTime,
+			NotificationEnabled: g.NotificationEnabled,
+			Icon:                g.Icon,
+			Color:               g.Color,
+			CoverImageURL:       g.CoverImageURL,
+			Order:               g.Order,
+			StartDate:           g.StartDate.StringPtr(),
+			EndDate:             g.EndDate.StringPtr(),
+			MaxExcusesPerDay:    g.MaxExcusesPerDay,
+			ArchivedAt:          g.ArchivedAt,
+			CreatedAt:           g.CreatedAt,
+			UpdatedAt:           g.UpdatedAt,
+		}
+	}
+
+	var excuses []struct {
+		models.ExcuseEntry
+		GoalTitle    *string
+		TemplateText *string
+	}
+	err := s.db.Table("excuse_entries").
+		Select("excuse_entries.*, goals.title AS goal_title, excuse_templates.text AS template_text").
+		Joins("LEFT JOIN goals ON goals.id = excuse_entries.goal_id").
+		Joins("LEFT JOIN excuse_templates ON excuse_templates.id = excuse_entries.template_id").
+		Where("excuse_entries.user_id = ? AND excuse_entries.deleted_at IS NULL", userID).
+		Order("excuse_entries.date asc, excuse_entries.created_at asc").
+		Scan(&excuses).Error
+	if err != nil {
+		return data, err
+	}
+	data.Excuses = make([]exportExcuse, len(excuses))
+	for i, e := range excuses {
+		data.Excuses[i] = exportExcuse{
+			ID:             e.ID,
+			GoalID:         e.GoalID,
+			Date:           string(e.Date),
+			ExcuseText:     e.ExcuseText,
+			TemplateID:     e.TemplateID,
+			TemplateText:   e.TemplateText,
+			Mood:           e.Mood,
+			Tags:           e.Tags,
+			Note:           e.Note,
+			AiGenerationID: e.AiGenerationID,
+			CreatedAt:      e.CreatedAt,
+			UpdatedAt:      e.UpdatedAt,
+		}
+		if e.GoalTitle != nil {
+			data.Excuses[i].GoalTitle = *e.GoalTitle
+		}
+	}
+
+	var generations []models.AiGeneration
+	if err := s.db.Where("user_id = ?", userID).Order("created_at asc").Find(&generations).Error; err != nil {
+		return data, err
+	}
+	data.AiGenerations = make([]exportAiGeneration, len(generations))
+	for i, g := range generations {
+		data.AiGenerations[i] = exportAiGeneration{
+			ID:         g.ID,
+			GoalID:     g.GoalID,
+			Date:       string(g.Date),
+			Tone:       g.Tone,
+			Context:    g.Context,
+			Candidates: g.Candidates,
+			Model:      g.Model,
+			CreatedAt:  g.CreatedAt,
+		}
+	}
+
+	return data, nil
+}
+
+// writeExportArchive writes one JSON file per resource, plus the excuses as CSV.
+func writeExportArchive(w io.Writer, data exportData) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name  string
+		value any
+	}{
+		{"profile.json", data.Profile},
+		{"plan.json", data.Plan},
+		{"plan_history.json", data.PlanHistory},
+		{"goals.json", data.Goals},
+		{"excuses.json", data.Excuses},
+		{"ai_generations.json", data.AiGenerations},
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.value); err != nil {
+			return err
+		}
+	}
+
+	fw, err := zw.Create("excuses.csv")
+	if err != nil {
+		return err
+	}
+	// BOM so that spreadsheet apps open the Japanese text as UTF-8
+	if _, err := fw.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(fw)
+	cw.Write([]string{"date", "goal", "excuse", "template", "created_at"})
+	for _, e := range data.Excuses {
+		template := ""
+		if e.TemplateText != nil {
+			template = *e.TemplateText
+		}
+		cw.Write([]string{e.Date, e.GoalTitle, e.ExcuseText, template, e.CreatedAt.Format(time.RFC3339)})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
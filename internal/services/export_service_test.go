@@ -0,0 +1,108 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteExportArchive(t *testing.T) {
+	templateID := "gravity-strong"
+	templateText := "今日は重力が強かった"
+	goalID := uuid.New()
+	data := exportData{
+		Profile: exportProfile{ID: "auth0|test", Locale: "ja", TimeZone: "Asia/Tokyo"},
+		Plan:    exportPlan{Plan: "free"},
+		Goals:   []exportGoal{{ID: goalID, Title: "本を読む"}},
+		Excuses: []exportExcuse{
+			{ID: uuid.New(), GoalID: goalID, GoalTitle: "本を読む", Date: "2025-01-01", ExcuseText: "眠かった, とても", CreatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)},
+			{ID: uuid.New(), GoalID: goalID, GoalTitle: "本を読む", Date: "2025-01-02", ExcuseText: templateText, TemplateID: &templateID, TemplateText: &templateText},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeExportArchive(&buf, data))
+
+	files := readArchive(t, buf.Bytes())
+	for _, name := range []string{"profile.json", "plan.json", "plan_history.json", "goals.json", "excuses.json", "ai_generations.json", "excuses.csv"} {
+		assert.Contains(t, files, name)
+	}
+
+	var excuses []exportExcuse
+	require.NoError(t, json.Unmarshal(files["excuses.json"], &excuses))
+	assert.Len(t, excuses, 2)
+	assert.Equal(t, templateText, *excuses[1].TemplateText)
+
+	assert.True(t, bytes.HasPrefix(files["excuses.csv"], []byte("\xEF\xBB\xBF")))
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(files["excuses.csv"], []byte("\xEF\xBB\xBF")))).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"date", "goal", "excuse", "template", "created_at"}, records[0])
+	assert.Equal(t, []string{"2025-01-01", "本を読む", "眠かった, とても", "", "2025-01-01T12:00:00Z"}, records[1])
+	assert.Equal(t, templateText, records[2][3])
+}
+
+func TestBuildArchive(t *testing.T) {
+	db, cleanup := testdb.Setup(t)
+	defer cleanup()
+
+	userID := "auth0|test"
+	start, end := models.Date("2025-01-01"), models.Date("2025-01-10")
+	goal := models.Goal{UserID: userID, Title: "本を読む", StartDate: &start, EndDate: &end}
+	db.Create(&goal)
+	db.Create(&models.ExcuseEntry{UserID: userID, GoalID: goal.ID, Date: "2025-01-02", ExcuseText: "眠かった"})
+	entitlements := NewEntitlementService(db)
+	_, err := entitlements.UpdatePlan(userID, "premium")
+	require.NoError(t, err)
+	_, err = entitlements.UpdatePlan(userID, "premium")
+	require.NoError(t, err)
+	_, err = entitlements.UpdatePlan(userID, "free")
+	require.NoError(t, err)
+
+	archive, err := NewExportService(db).BuildArchive(userID)
+	require.NoError(t, err)
+	files := readArchive(t, archive)
+
+	var goals []exportGoal
+	require.NoError(t, json.Unmarshal(files["goals.json"], &goals))
+	require.Len(t, goals, 1)
+	assert.Equal(t, "2025-01-01", *goals[0].StartDate)
+	assert.Equal(t, "2025-01-10", *goals[0].EndDate)
+
+	var excuses []exportExcuse
+	require.NoError(t, json.Unmarshal(files["excuses.json"], &excuses))
+	require.Len(t, excuses, 1)
+	assert.Equal(t, "2025-01-02", excuses[0].Date)
+
+	var history []exportPlanChange
+	require.NoError(t, json.Unmarshal(files["plan_history.json"], &history))
+	// Setting the same plan again is not a change
+	require.Len(t, history, 2)
+	assert.Equal(t, "premium", history[0].Plan)
+	assert.Equal(t, "free", history[1].Plan)
+}
+
+// readArchive returns the contents of the files in a ZIP archive by name.
+func readArchive(t *testing.T, archive []byte) map[string][]byte {
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = b
+	}
+	return files
+}
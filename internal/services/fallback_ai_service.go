@@ -0,0 +1,126 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	breakerFailureThreshold = 3
+	breakerCooldown         = 30 * time.Second
+	aiCacheCapacity         = 256
+	aiCacheTTL              = 10 * time.Minute
+)
+
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// circuitBreaker opens after consecutive failures. Once the cooldown has passed it is half-open:
+// a single trial request goes through while the others are still refused, and its outcome
+// closes the breaker or opens it for another cooldown.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	probing   bool // A trial request is in flight
+	now       func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a request may go through. Every allowed request must be followed by record.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.probing || b.now().Sub(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if err == nil {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+}
+
+type aiProvider struct {
+	service AIService
+	breaker *circuitBreaker
+}
+
+// FallbackAIService tries providers in order and returns the first successful generation.
+type FallbackAIService struct {
+	providers []aiProvider
+}
+
+func NewFallbackAIService(providers ...AIService) *FallbackAIService {
+	s := &FallbackAIService{}
+	for _, p := range providers {
+		s.providers = append(s.providers, aiProvider{
+			service: p,
+			breaker: newCircuitBreaker(breakerFailureThreshold, breakerCooldown),
+		})
+	}
+	return s
+}
+
+func (s *FallbackAIService) GenerateExcuse(req ExcuseGenerationRequest) (*GeneratedExcuses, error) {
+	var errs []error
+	for i, p := range s.providers {
+		if !p.breaker.allow() {
+			errs = append(errs, fmt.Errorf("provider %d: %w", i, errCircuitOpen))
+			continue
+		}
+
+		generated, err := p.service.GenerateExcuse(req)
+		p.breaker.record(err)
+		if err == nil {
+			return generated, nil
+		}
+		log.Printf("AI provider %d failed, falling back: %v", i, err)
+		errs = append(errs, fmt.Errorf("provider %d: %w", i, err))
+	}
+	return nil, errors.Join(errs...)
+}
+
+// NewAIServiceFromEnv chains the LLMs configured by AI_PRIMARY_* and AI_SECONDARY_*
+// (BASE_URL, API_KEY, MODEL) with the template generator, behind a cache.
+// The mock stands in for the LLMs when none is configured.
+func NewAIServiceFromEnv(db *gorm.DB) AIService {
+	var providers []AIService
+	for _, prefix := range []string{"AI_PRIMARY", "AI_SECONDARY"} {
+		baseURL := os.Getenv(prefix + "_BASE_URL")
+		if baseURL == "" {
+			continue
+		}
+		providers = append(providers, NewLLMAIService(baseURL, os.Getenv(prefix+"_API_KEY"), os.Getenv(prefix+"_MODEL")))
+	}
+	if len(providers) == 0 {
+		providers = append(providers, NewMockAIService())
+	}
+	providers = append(providers, NewTemplateAIService(db))
+
+	return NewCachedAIService(NewFallbackAIService(providers...), aiCacheCapacity, aiCacheTTL)
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubAIService struct {
+	calls int
+	model string
+	err   error
+}
+
+func (s *stubAIService) GenerateExcuse(req ExcuseGenerationRequest) (*GeneratedExcuses, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &GeneratedExcuses{Candidates: []string{req.Context}, Model: s.model}, nil
+}
+
+func TestFallbackAIService(t *testing.T) {
+	t.Run("FallsBackToNextProvider", func(t *testing.T) {
+		primary := &stubAIService{model: "primary", err: errors.New("down")}
+		secondary := &stubAIService{model: "secondary"}
+		service := NewFallbackAIService(primary, secondary)
+
+		generated, err := service.GenerateExcuse(ExcuseGenerationRequest{Context: "c"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "secondary", generated.Model)
+		assert.Equal(t, 1, primary.calls)
+	})
+
+	t.Run("AllProvidersFail", func(t *testing.T) {
+		service := NewFallbackAIService(&stubAIService{err: errors.New("down")}, &stubAIService{err: errors.New("down")})
+
+		_, err := service.GenerateExcuse(ExcuseGenerationRequest{})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("CircuitBreakerSkipsFailingProvider", func(t *testing.T) {
+		primary := &stubAIService{model: "primary", err: errors.New("down")}
+		secondary := &stubAIService{model: "secondary"}
+		service := NewFallbackAIService(primary, secondary)
+		now := time.Now()
+		service.providers[0].breaker.now = func() time.Time { return now }
+
+		for i := 0; i < breakerFailureThreshold+2; i++ {
+			service.GenerateExcuse(ExcuseGenerationRequest{})
+		}
+		assert.Equal(t, breakerFailureThreshold, primary.calls)
+
+		// Half-open after cooldown: one trial request reaches the provider again
+		primary.err = nil
+		now = now.Add(breakerCooldown)
+		generated, err := service.GenerateExcuse(ExcuseGenerationRequest{})
+		assert.NoError(t, err)
+		assert.Equal(t, "primary", generated.Model)
+		assert.Equal(t, breakerFailureThreshold+1, primary.calls)
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	open := func() (*circuitBreaker, *time.Time) {
+		breaker := newCircuitBreaker(2, time.Minute)
+		now := time.Now()
+		breaker.now = func() time.Time { return now }
+		for i := 0; i < 2; i++ {
+			breaker.allow()
+			breaker.record(errors.New("down"))
+		}
+		return breaker, &now
+	}
+
+	t.Run("HalfOpenAdmitsOneProbe", func(t *testing.T) {
+		breaker, now := open()
+		assert.False(t, breaker.allow())
+
+		*now = now.Add(time.Minute)
+		assert.True(t, breaker.allow())
+		// Still open while the probe is in flight
+		assert.False(t, breaker.allow())
+
+		breaker.record(nil)
+		assert.True(t, breaker.allow())
+		assert.True(t, breaker.allow())
+	})
+
+	t.Run("FailedProbeReopens", func(t *testing.T) {
+		breaker, now := open()
+
+		*now = now.Add(time.Minute)
+		assert.True(t, breaker.allow())
+		breaker.record(errors.New("down"))
+		assert.False(t, breaker.allow())
+
+		*now = now.Add(time.Minute)
+		assert.True(t, breaker.allow())
+	})
+}
+
+func TestCachedAIService(t *testing.T) {
+	goalID := uuid.New()
+
+	t.Run("HitsCacheForIdenticalRequest", func(t *testing.T) {
+		stub := &stubAIService{model: "stub"}
+		service := NewCachedAIService(stub, 10, time.Minute)
+		req := ExcuseGenerationRequest{GoalID: goalID, Tone: "surreal", Context: "c"}
+
+		service.GenerateExcuse(req)
+		generated, err := service.GenerateExcuse(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"c"}, generated.Candidates)
+		assert.Equal(t, 1, stub.calls)
+
+		service.GenerateExcuse(ExcuseGenerationRequest{GoalID: goalID, Tone: "philosophical", Context: "c"})
+		assert.Equal(t, 2, stub.calls)
+	})
+
+	t.Run("ExpiresAfterTTL", func(t *testing.T) {
+		stub := &stubAIService{model: "stub"}
+		service := NewCachedAIService(stub, 10, time.Minute)
+		now := time.Now()
+		service.now = func() time.Time { return now }
+		req := ExcuseGenerationRequest{GoalID: goalID, Context: "c"}
+
+		service.GenerateExcuse(req)
+		now = now.Add(2 * time.Minute)
+		service.GenerateExcuse(req)
+
+		assert.Equal(t, 2, stub.calls)
+	})
+
+	t.Run("EvictsLeastRecentlyUsed", func(t *testing.T) {
+		stub := &stubAIService{model: "stub"}
+		service := NewCachedAIService(stub, 2, time.Minute)
+		a := ExcuseGenerationRequest{GoalID: goalID, Context: "a"}
+		b := ExcuseGenerationRequest{GoalID: goalID, Context: "b"}
+		c := ExcuseGenerationRequest{GoalID: goalID, Context: "c"}
+
+		service.GenerateExcuse(a)
+		service.GenerateExcuse(b)
+		service.GenerateExcuse(a) // a is now most recently used
+		service.GenerateExcuse(c) // evicts b
+		assert.Equal(t, 3, stub.calls)
+
+		service.GenerateExcuse(a)
+		assert.Equal(t, 3, stub.calls)
+		service.GenerateExcuse(b)
+		assert.Equal(t, 4, stub.calls)
+	})
+
+	t.Run("DoesNotCacheErrors", func(t *testing.T) {
+		stub := &stubAIService{err: errors.New("down")}
+		service := NewCachedAIService(stub, 10, time.Minute)
+		req := ExcuseGenerationRequest{GoalID: goalID}
+
+		service.GenerateExcuse(req)
+		service.GenerateExcuse(req)
+
+		assert.Equal(t, 2, stub.calls)
+	})
+	t.Run("DoesNotCacheTemplateFallback", func(t *testing.T) {
+		llm := &stubAIService{err: errors.New("down")}
+		templates := &stubAIService{model: templateModel}
+		service := NewCachedAIService(NewFallbackAIService(llm, templates), 10, time.Minute)
+		req := ExcuseGenerationRequest{GoalID: goalID, Context: "c"}
+
+		generated, _ := service.GenerateExcuse(req)
+		assert.Equal(t, templateModel, generated.Model)
+
+		// Once the LLM is back, the same request reaches it instead of the cached templates
+		llm.err = nil
+		llm.model = "llm"
+		generated, _ = service.GenerateExcuse(req)
+		assert.Equal(t, "llm", generated.Model)
+		assert.Equal(t, 2, llm.calls)
+	})
+}
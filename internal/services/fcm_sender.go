@@ -0,0 +1,169 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	fcmScope    = "https://www.googleapis.com/auth/firebase.messaging"
+	fcmEndpoint = "https://fcm.googleapis.com/v1/projects/%s/messages:send"
+)
+
+type fcmServiceAccount struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// FCMSender sends notifications with the FCM HTTP v1 API, authenticated as a service account.
+type FCMSender struct {
+	account  fcmServiceAccount
+	key      *rsa.PrivateKey
+	endpoint string
+	client   *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewFCMSender creates a sender from the service account JSON downloaded from the Firebase console.
+func NewFCMSender(serviceAccountJSON []byte) (*FCMSender, error) {
+	var account fcmServiceAccount
+	if err := json.Unmarshal(serviceAccountJSON, &account); err != nil {
+		return nil, fmt.Errorf("failed to parse FCM service account: %w", err)
+	}
+	if account.ProjectID == "" || account.ClientEmail == "" || account.TokenURI == "" {
+		return nil, errors.New("FCM service account is missing project_id, client_email or token_uri")
+	}
+
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse FCM private key: %w", err)
+	}
+
+	return &FCMSender{
+		account:  account,
+		key:      key,
+		endpoint: fmt.Sprintf(fcmEndpoint, account.ProjectID),
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// oauthToken exchanges a self-signed JWT for an OAuth2 access token (RFC 7523) and caches it until shortly before expiry.
+func (s *FCMSender) oauthToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessToken != "" && time.Now().Before(s.expiresAt) {
+		return s.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   s.account.ClientEmail,
+		"scope": fcmScope,
+		"aud":   s.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(s.key)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("FCM token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("FCM token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode FCM token response: %w", err)
+	}
+
+	s.accessToken = token.AccessToken
+	s.expiresAt = now.Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	return s.accessToken, nil
+}
+
+func (s *FCMSender) Send(ctx context.Context, deviceToken string, n Notification) error {
+	payload, err := json.Marshal(map[string]any{
+		"message": map[string]any{
+			"token": deviceToken,
+			"notification": map[string]string{
+				"title": n.Title,
+				"body":  n.Body,
+			},
+			"data": map[string]string{
+				"goalId": n.GoalID.String(),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	accessToken, err := s.oauthToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("FCM request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var fcmErr struct {
+		Error struct {
+			Status string `json:"status"`
+		} `json:"error"`
+	}
+	json.NewDecoder(resp.Body).Decode(&fcmErr)
+	if resp.StatusCode == http.StatusNotFound || fcmErr.Error.Status == "UNREGISTERED" {
+		return fmt.Errorf("%w: %s", ErrInvalidPushToken, fcmErr.Error.Status)
+	}
+	return fmt.Errorf("FCM returned status %d: %s", resp.StatusCode, fcmErr.Error.Status)
+}
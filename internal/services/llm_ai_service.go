@@ -0,0 +1,109 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const llmCandidateCount = 3
+
+// Matches list markers such as "- ", "・", "1. " or "2) " the model may prepend despite the instructions
+var listMarkerPattern = regexp.MustCompile(`^(?:[-・*]|\d+[.)．）])\s*`)
+
+// LLMAIService generates excuses with an OpenAI compatible chat completions API.
+type LLMAIService struct {
+	baseURL string
+	apiKey  string
+	model   string
+	client  *http.Client
+}
+
+func NewLLMAIService(baseURL string, apiKey string, model string) *LLMAIService {
+	return &LLMAIService{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		model:   model,
+		client:  &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+func (s *LLMAIService) GenerateExcuse(req ExcuseGenerationRequest) (*GeneratedExcuses, error) {
+	prompt := fmt.Sprintf("目標「%s」を今日できなかった言い訳を%d個考えてください。", req.GoalTitle, llmCandidateCount)
+	if req.ToneInstructions != "" {
+		prompt += "\n" + req.ToneInstructions
+	}
+	if req.Context != "" {
+		prompt += fmt.Sprintf("\n状況: %s", req.Context)
+	}
+
+	body, err := json.Marshal(chatCompletionRequest{
+		Model: s.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: "あなたは習慣化アプリのためにユーモアのある言い訳を考えるアシスタントです。言い訳は1行に1つ、番号や記号を付けずに出力してください。"},
+			{Role: "user", Content: prompt},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, s.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("llm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("llm returned status %d", resp.StatusCode)
+	}
+
+	var completion chatCompletionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return nil, fmt.Errorf("failed to decode llm response: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return nil, errors.New("llm returned no choices")
+	}
+
+	var candidates []string
+	for _, line := range strings.Split(completion.Choices[0].Message.Content, "\n") {
+		line = strings.TrimSpace(listMarkerPattern.ReplaceAllString(strings.TrimSpace(line), ""))
+		if line != "" {
+			candidates = append(candidates, line)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, errors.New("llm returned no candidates")
+	}
+
+	return &GeneratedExcuses{Candidates: candidates, Model: s.model}, nil
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type Moderator interface {
+	Moderate(text string) (*ModerationResult, error)
+}
+
+type ModerationAction string
+
+const (
+	ModerationActionReject ModerationAction = "reject"
+	ModerationActionMask   ModerationAction = "mask"
+	ModerationActionFlag   ModerationAction = "flag"
+)
+
+type ModerationRule struct {
+	Pattern *regexp.Regexp
+	Action  ModerationAction
+}
+
+// RuleModerator is the local keyword/regex rule engine.
+type RuleModerator struct {
+	rules []ModerationRule
+}
+
+func NewRuleModerator(rules []ModerationRule) *RuleModerator {
+	return &RuleModerator{rules: rules}
+}
+
+func (m *RuleModerator) Moderate(text string) (*ModerationResult, error) {
+	result := &ModerationResult{Text: text}
+	for _, rule := range m.rules {
+		if !rule.Pattern.MatchString(result.Text) {
+			continue
+		}
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%s:%s", rule.Action, rule.Pattern.String()))
+		switch rule.Action {
+		case ModerationActionReject:
+			result.Rejected = true
+		case ModerationActionMask:
+			result.Text = rule.Pattern.ReplaceAllStringFunc(result.Text, func(s string) string {
+				return strings.Repeat("＊", utf8.RuneCountInString(s))
+			})
+		case ModerationActionFlag:
+			result.Flagged = true
+		}
+	}
+	return result, nil
+}
+
+// ClassifierModerator delegates to an external classification API.
+// The API receives {"text": "..."} and answers {"action": "allow|reject|flag", "categories": [...]}.
+type ClassifierModerator struct {
+	endpoint string
+	client   *http.Client
+}
+
+func NewClassifierModerator(endpoint string) *ClassifierModerator {
+	return &ClassifierModerator{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (m *ClassifierModerator) Moderate(text string) (*ModerationResult, error) {
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := m.client.Post(m.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("moderation classifier request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("moderation classifier returned status %d", resp.StatusCode)
+	}
+
+	var classified struct {
+		Action     string   `json:"action"`
+		Categories []string `json:"categories"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&classified); err != nil {
+		return nil, fmt.Errorf("failed to decode moderation classifier response: %w", err)
+	}
+
+	result := &ModerationResult{Text: text}
+	for _, category := range classified.Categories {
+		result.Reasons = append(result.Reasons, "classifier:"+category)
+	}
+	switch ModerationAction(classified.Action) {
+	case ModerationActionReject:
+		result.Rejected = true
+	case ModerationActionFlag:
+		result.Flagged = true
+	}
+	return result, nil
+}
+
+// ChainModerator runs moderators in order, passing the (possibly masked) text along.
+type ChainModerator struct {
+	moderators []Moderator
+}
+
+func NewChainModerator(moderators ...Moderator) *ChainModerator {
+	return &ChainModerator{moderators: moderators}
+}
+
+func (m *ChainModerator) Moderate(text string) (*ModerationResult, error) {
+	result := &ModerationResult{Text: text}
+	for _, moderator := range m.moderators {
+		r, err := moderator.Moderate(result.Text)
+		if err != nil {
+			return nil, err
+		}
+		result.Text = r.Text
+		result.Rejected = result.Rejected || r.Rejected
+		result.Flagged = result.Flagged || r.Flagged
+		result.Reasons = append(result.Reasons, r.Reasons...)
+	}
+	return result, nil
+}
+
+// Default rules: mask contact details so they are never shared, flag threats for review.
+var defaultModerationPatterns = map[ModerationAction][]string{
+	ModerationActionMask: {
+		`[\w.+-]+@[\w-]+\.[\w.-]+`,
+		`0\d{1,4}-?\d{1,4}-?\d{4}`,
+	},
+	ModerationActionFlag: {
+		`死ね`,
+		`殺す`,
+	},
+}
+
+// NewModeratorFromEnv builds the rule engine from MODERATION_{REJECT,MASK,FLAG}_PATTERNS
+// (regular expressions, one per line since commas are part of the syntax; defaults used when unset)
+// and appends the external classifier when MODERATION_CLASSIFIER_URL is set.
+func NewModeratorFromEnv() (Moderator, error) {
+	envKeys := []struct {
+		action ModerationAction
+		key    string
+	}{
+		{ModerationActionReject, "MODERATION_REJECT_PATTERNS"},
+		{ModerationActionMask, "MODERATION_MASK_PATTERNS"},
+		{ModerationActionFlag, "MODERATION_FLAG_PATTERNS"},
+	}
+
+	var rules []ModerationRule
+	for _, k := range envKeys {
+		patterns := defaultModerationPatterns[k.action]
+		if v, ok := os.LookupEnv(k.key); ok {
+			patterns = nil
+			for _, p := range strings.Split(v, "\n") {
+				if p = strings.TrimSpace(p); p != "" {
+					patterns = append(patterns, p)
+				}
+			}
+		}
+		for _, p := range patterns {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern in %s: %w", k.key, err)
+			}
+			rules = append(rules, ModerationRule{Pattern: re, Action: k.action})
+		}
+	}
+
+	moderators := []Moderator{NewRuleModerator(rules)}
+	if endpoint := os.Getenv("MODERATION_CLASSIFIER_URL"); endpoint != "" {
+		moderators = append(moderators, NewClassifierModerator(endpoint))
+	}
+	return NewChainModerator(moderators...), nil
+}
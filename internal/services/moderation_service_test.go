@@ -0,0 +1,170 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubModerator struct {
+	result *ModerationResult
+	err    error
+	got    string
+}
+
+func (m *stubModerator) Moderate(text string) (*ModerationResult, error) {
+	m.got = text
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.result, nil
+}
+
+func TestRuleModerator(t *testing.T) {
+	moderator := NewRuleModerator([]ModerationRule{
+		{Pattern: regexp.MustCompile(`禁止`), Action: ModerationActionReject},
+		{Pattern: regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.-]+`), Action: ModerationActionMask},
+		{Pattern: regexp.MustCompile(`@`), Action: ModerationActionFlag},
+		{Pattern: regexp.MustCompile(`殺す`), Action: ModerationActionFlag},
+	})
+
+	t.Run("Allowed", func(t *testing.T) {
+		result, err := moderator.Moderate("猫が膝で寝ていた")
+
+		require.NoError(t, err)
+		assert.Equal(t, "猫が膝で寝ていた", result.Text)
+		assert.False(t, result.Rejected)
+		assert.False(t, result.Flagged)
+		assert.Empty(t, result.Reasons)
+	})
+
+	t.Run("MaskBeforeLaterRules", func(t *testing.T) {
+		result, err := moderator.Moderate("連絡は a@b.jp まで")
+
+		require.NoError(t, err)
+		assert.Equal(t, "連絡は ＊＊＊＊＊＊ まで", result.Text)
+		// The masked address no longer matches the flag rule that follows
+		assert.False(t, result.Flagged)
+		assert.Len(t, result.Reasons, 1)
+	})
+
+	t.Run("RejectKeepsOtherRules", func(t *testing.T) {
+		result, err := moderator.Moderate("禁止ワード、殺す、a@b.jp")
+
+		require.NoError(t, err)
+		assert.True(t, result.Rejected)
+		assert.True(t, result.Flagged)
+		assert.NotContains(t, result.Text, "a@b.jp")
+		assert.Len(t, result.Reasons, 3)
+	})
+}
+
+func TestChainModerator(t *testing.T) {
+	t.Run("PassesMaskedTextAlong", func(t *testing.T) {
+		first := NewRuleModerator([]ModerationRule{
+			{Pattern: regexp.MustCompile(`秘密`), Action: ModerationActionMask},
+		})
+		second := &stubModerator{result: &ModerationResult{Text: "＊＊の話", Flagged: true, Reasons: []string{"classifier:other"}}}
+
+		result, err := NewChainModerator(first, second).Moderate("秘密の話")
+
+		require.NoError(t, err)
+		assert.Equal(t, "＊＊の話", second.got)
+		assert.Equal(t, "＊＊の話", result.Text)
+		assert.False(t, result.Rejected)
+		assert.True(t, result.Flagged)
+		assert.Len(t, result.Reasons, 2)
+	})
+
+	t.Run("StopsOnError", func(t *testing.T) {
+		failing := &stubModerator{err: errors.New("down")}
+		last := &stubModerator{result: &ModerationResult{}}
+
+		_, err := NewChainModerator(failing, last).Moderate("text")
+
+		assert.Error(t, err)
+		assert.Empty(t, last.got)
+	})
+}
+
+func TestClassifierModerator(t *testing.T) {
+	classify := func(status int, body string) (*ModerationResult, error) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+		}))
+		defer server.Close()
+		return NewClassifierModerator(server.URL).Moderate("text")
+	}
+
+	t.Run("Reject", func(t *testing.T) {
+		result, err := classify(http.StatusOK, `{"action": "reject", "categories": ["violence"]}`)
+
+		require.NoError(t, err)
+		assert.True(t, result.Rejected)
+		assert.False(t, result.Flagged)
+		assert.Equal(t, []string{"classifier:violence"}, result.Reasons)
+		assert.Equal(t, "text", result.Text)
+	})
+
+	t.Run("Flag", func(t *testing.T) {
+		result, err := classify(http.StatusOK, `{"action": "flag", "categories": ["harassment"]}`)
+
+		require.NoError(t, err)
+		assert.True(t, result.Flagged)
+		assert.False(t, result.Rejected)
+	})
+
+	t.Run("Allow", func(t *testing.T) {
+		result, err := classify(http.StatusOK, `{"action": "allow"}`)
+
+		require.NoError(t, err)
+		assert.False(t, result.Rejected)
+		assert.False(t, result.Flagged)
+		assert.Empty(t, result.Reasons)
+	})
+
+	t.Run("ErrorStatus", func(t *testing.T) {
+		_, err := classify(http.StatusServiceUnavailable, "")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidBody", func(t *testing.T) {
+		_, err := classify(http.StatusOK, "not json")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestNewModeratorFromEnv(t *testing.T) {
+	t.Run("PatternsPerLine", func(t *testing.T) {
+		t.Setenv("MODERATION_REJECT_PATTERNS", "a{2,3}\n\n  b,c  ")
+		t.Setenv("MODERATION_MASK_PATTERNS", "")
+		t.Setenv("MODERATION_FLAG_PATTERNS", "")
+		t.Setenv("MODERATION_CLASSIFIER_URL", "")
+
+		moderator, err := NewModeratorFromEnv()
+		require.NoError(t, err)
+
+		result, _ := moderator.Moderate("xaay")
+		assert.True(t, result.Rejected)
+		result, _ = moderator.Moderate("b,c")
+		assert.True(t, result.Rejected)
+		// Commas are part of the pattern, not separators
+		result, _ = moderator.Moderate("b")
+		assert.False(t, result.Rejected)
+	})
+
+	t.Run("InvalidPattern", func(t *testing.T) {
+		t.Setenv("MODERATION_REJECT_PATTERNS", "(")
+
+		_, err := NewModeratorFromEnv()
+		assert.Error(t, err)
+	})
+}
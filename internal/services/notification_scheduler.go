@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+	"what-went-wrong-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationScheduler reminds users of goals without an excuse for today
+// at the goal's notification time, in the user's time zone. Users who turned reminders off or are
+// waiting for account deletion are skipped.
+type NotificationScheduler struct {
+	db       *gorm.DB
+	notifier Notifier
+}
+
+func NewNotificationScheduler(db *gorm.DB, notifier Notifier) *NotificationScheduler {
+	return &NotificationScheduler{db: db, notifier: notifier}
+}
+
+// Run ticks at the start of every minute until ctx is cancelled.
+// When a tick overruns, the minutes that passed meanwhile are ticked on the next wake-up.
+func (s *NotificationScheduler) Run(ctx context.Context) {
+	last := time.Now().Truncate(time.Minute)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(last.Add(time.Minute))):
+			now := time.Now()
+			for _, minute := range minutesSince(last, now) {
+				if err := s.Tick(ctx, minute); err != nil {
+					log.Printf("Notification scheduler tick failed: %v", err)
+				}
+			}
+			last = now.Truncate(time.Minute)
+		}
+	}
+}
+
+// minutesSince returns the minute marks after last up to and including now.
+func minutesSince(last, now time.Time) []time.Time {
+	var minutes []time.Time
+	for minute := last.Truncate(time.Minute).Add(time.Minute); !minute.After(now); minute = minute.Add(time.Minute) {
+		minutes = append(minutes, minute)
+	}
+	return minutes
+}
+
+// Tick notifies every goal due at now.
+func (s *NotificationScheduler) Tick(ctx context.Context, now time.Time) error {
+	// Goals are processed per time zone. Users without a profile use the default one.
+	var timeZones []string
+	err := s.db.Model(&models.Goal{}).
+		Select("DISTINCT COALESCE(users.time_zone, ?)", DefaultTimeZone).
+		Joins("LEFT JOIN users ON users.id = goals.user_id").
+		Where("goals.notification_enabled = ? AND goals.archived_at IS NULL", true).
+		Where("COALESCE(users.reminder_notifications_enabled, ?)", true).
+		Where("users.deletion_scheduled_at IS NULL").
+		Scan(&timeZones).Error
+	if err != nil {
+		return err
+	}
+
+	for _, timeZone := range timeZones {
+		if err := s.notifyDue(ctx, timeZone, now.In(LoadLocation(timeZone))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *NotificationScheduler) notifyDue(ctx context.Context, timeZone string, local time.Time) error {
+	today := local.Format("2006-01-02")
+
+	var goals []models.Goal
+	err := s.db.
+		Select("goals.*").
+		Joins("LEFT JOIN users ON users.id = goals.user_id").
+		Where("COALESCE(users.time_zone, ?) = ?", DefaultTimeZone, timeZone).
+		Where("COALESCE(users.reminder_notifications_enabled, ?)", true).
+		Where("users.deletion_scheduled_at IS NULL").
+		Where("goals.notification_enabled = ? AND goals.notification_time = ?", true, local.Format("15:04")).
+		Where("goals.archived_at IS NULL").
+		Where("(goals.start_date IS NULL OR goals.start_date <= ?) AND (goals.end_date IS NULL OR goals.end_date >= ?)", today, today).
+		Where("NOT EXISTS (SELECT 1 FROM pause_periods WHERE pause_periods.user_id = goals.user_id AND (pause_periods.goal_id = goals.id OR pause_periods.goal_id IS NULL) AND ? BETWEEN pause_periods.start_date AND pause_periods.end_date)", today).
+		Where("NOT EXISTS (SELECT 1 FROM excuse_entries WHERE excuse_entries.goal_id = goals.id AND excuse_entries.date = ? AND excuse_entries.deleted_at IS NULL)", today).
+		Where("NOT EXISTS (SELECT 1 FROM notification_logs WHERE notification_logs.goal_id = goals.id AND notification_logs.date = ?)", today).
+		Find(&goals).Error
+	if err != nil {
+		return err
+	}
+
+	for _, goal := range goals {
+		// Claim the goal for today before sending so that a goal is never notified twice,
+		// even with several instances running
+		entry := models.NotificationLog{UserID: goal.UserID, GoalID: goal.ID, Date: models.Date(today), Status: "pending"}
+		result := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entry)
+		if result.Error != nil {
+			log.Printf("Failed to record notification for goal %s: %v", goal.ID, result.Error)
+			continue
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		status := "sent"
+		if err := s.notifier.Notify(ctx, Notification{
+			UserID: goal.UserID,
+			GoalID: goal.ID,
+			Title:  goal.Title,
+			Body:   "今日はできましたか？できなかったら言い訳を残しておきましょう。",
+		}); err != nil {
+			log.Printf("Failed to notify goal %s: %v", goal.ID, err)
+			status = "failed"
+		}
+		if err := s.db.Model(&entry).Update("status", status).Error; err != nil {
+			log.Printf("Failed to update notification status for goal %s: %v", goal.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+	"what-went-wrong-api/internal/models"
+	"what-went-wrong-api/internal/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinutesSince(t *testing.T) {
+	last := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	t.Run("OnTime", func(t *testing.T) {
+		minutes := minutesSince(last, last.Add(time.Minute+50*time.Millisecond))
+		assert.Equal(t, []time.Time{last.Add(time.Minute)}, minutes)
+	})
+
+	t.Run("Overrun", func(t *testing.T) {
+		minutes := minutesSince(last, last.Add(3*time.Minute+10*time.Second))
+		assert.Equal(t, []time.Time{last.Add(time.Minute), last.Add(2 * time.Minute), last.Add(3 * time.Minute)}, minutes)
+	})
+
+	t.Run("EarlyWakeUp", func(t *testing.T) {
+		assert.Empty(t, minutesSince(last, last.Add(59*time.Second)))
+	})
+}
+
+func TestNotificationScheduler(t *testing.T) {
+	db, cleanup := testdb.Setup(t)
+	defer cleanup()
+
+	// 21:00 in Tokyo and 07:00 in New York
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	today := "2025-01-15"
+
+	db.Create(&models.User{ID: "auth0|tokyo", TimeZone: "Asia/Tokyo"})
+	db.Create(&models.User{ID: "auth0|ny", TimeZone: "America/New_York"})
+
+	evening := "21:00"
+	morning := "07:00"
+	createGoal := func(userID string, notificationTime *string, enabled bool) models.Goal {
+		goal := models.Goal{UserID: userID, Title: "Run", NotificationTime: notificationTime, NotificationEnabled: enabled}
+		db.Create(&goal)
+		return goal
+	}
+	tokyoDue := createGoal("auth0|tokyo", &evening, true)
+	tokyoDone := createGoal("auth0|tokyo", &evening, true)
+	createGoal("auth0|tokyo", &evening, false)
+	createGoal("auth0|ny", &evening, true)
+	nyDue := createGoal("auth0|ny", &morning, true)
+
+	db.Create(&models.ExcuseEntry{UserID: "auth0|tokyo", GoalID: tokyoDone.ID, Date: models.Date(today), ExcuseText: "雨だった"})
+
+	notifier := NewFakeNotifier()
+	scheduler := NewNotificationScheduler(db, notifier)
+
+	notifiedGoals := func() []uuid.UUID {
+		var ids []uuid.UUID
+		for _, n := range notifier.Sent() {
+			ids = append(ids, n.GoalID)
+		}
+		return ids
+	}
+
+	t.Run("LocalTimeZone", func(t *testing.T) {
+		assert.NoError(t, scheduler.Tick(context.Background(), now))
+
+		// Goals with an excuse for today, reminders off, or due at another local time are skipped
+		assert.ElementsMatch(t, []uuid.UUID{tokyoDue.ID, nyDue.ID}, notifiedGoals())
+	})
+
+	t.Run("SameMinuteTwice", func(t *testing.T) {
+		assert.NoError(t, scheduler.Tick(context.Background(), now))
+
+		assert.Len(t, notifier.Sent(), 2)
+		var count int64
+		db.Model(&models.NotificationLog{}).Where("goal_id = ? AND date = ?", tokyoDue.ID, today).Count(&count)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("ConcurrentTicks", func(t *testing.T) {
+		// Two instances ticking the same minute both pass the NOT EXISTS check;
+		// the unique index on the log lets only one of them send
+		tomorrow := now.Add(24 * time.Hour)
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, NewNotificationScheduler(db, notifier).Tick(context.Background(), tomorrow))
+			}()
+		}
+		wg.Wait()
+
+		// The excuse was for yesterday, so every enabled goal due at this time is notified once
+		assert.Len(t, notifier.Sent(), 5)
+		assert.ElementsMatch(t, []uuid.UUID{tokyoDue.ID, nyDue.ID, tokyoDue.ID, tokyoDone.ID, nyDue.ID}, notifiedGoals())
+	})
+
+	t.Run("SentStatus", func(t *testing.T) {
+		var entry models.NotificationLog
+		db.First(&entry, "goal_id = ?", nyDue.ID)
+		assert.Equal(t, "sent", entry.Status)
+		assert.Equal(t, models.Date(today), entry.Date)
+	})
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+)
+
+// ErrInvalidPushToken is returned by a PushSender when the provider reports the token as no longer valid.
+var ErrInvalidPushToken = errors.New("invalid push token")
+
+// Notifier delivers a notification to a user.
+type Notifier interface {
+	Notify(ctx context.Context, n Notification) error
+}
+
+// PushSender delivers a notification to a single device token of a push provider.
+type PushSender interface {
+	Send(ctx context.Context, token string, n Notification) error
+}
+
+// NopNotifier drops every notification.
+type NopNotifier struct{}
+
+func (NopNotifier) Notify(ctx context.Context, n Notification) error {
+	return nil
+}
+
+// FakeNotifier keeps notifications in memory instead of delivering them.
+type FakeNotifier struct {
+	mu   sync.Mutex
+	sent []Notification
+}
+
+func NewFakeNotifier() *FakeNotifier {
+	return &FakeNotifier{}
+}
+
+func (f *FakeNotifier) Notify(ctx context.Context, n Notification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	log.Printf("Notification to %s (goal %s): %s", n.UserID, n.GoalID, n.Body)
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+func (f *FakeNotifier) Sent() []Notification {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Notification(nil), f.sent...)
+}
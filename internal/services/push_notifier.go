@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"what-went-wrong-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PushNotifier fans a notification out to every registered device of the user,
+// and removes devices whose token the push provider reports as invalid.
+type PushNotifier struct {
+	db      *gorm.DB
+	senders map[string]PushSender // keyed by Device.Platform
+}
+
+func NewPushNotifier(db *gorm.DB, senders map[string]PushSender) *PushNotifier {
+	return &PushNotifier{db: db, senders: senders}
+}
+
+func (p *PushNotifier) Notify(ctx context.Context, n Notification) error {
+	var devices []models.Device
+	if err := p.db.Where("user_id = ?", n.UserID).Find(&devices).Error; err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, device := range devices {
+		sender, ok := p.senders[device.Platform]
+		if !ok {
+			continue
+		}
+
+		err := sender.Send(ctx, device.PushToken, n)
+		if errors.Is(err, ErrInvalidPushToken) {
+			if err := p.db.Delete(&device).Error; err != nil {
+				log.Printf("Failed to prune device %s: %v", device.ID, err)
+			}
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("device %s: %w", device.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewNotifierFromEnv builds a PushNotifier with the APNs sender (APNS_KEY_FILE, APNS_KEY_ID, APNS_TEAM_ID,
+// APNS_TOPIC, APNS_PRODUCTION) and the FCM sender (FCM_SERVICE_ACCOUNT_FILE) that are configured.
+// When neither is configured, the in-memory FakeNotifier is returned for development, and a NopNotifier
+// in production so that reminders are not written to the log.
+func NewNotifierFromEnv(db *gorm.DB) (Notifier, error) {
+	senders := map[string]PushSender{}
+
+	if keyFile := os.Getenv("APNS_KEY_FILE"); keyFile != "" {
+		key, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read APNS_KEY_FILE: %w", err)
+		}
+		sender, err := NewAPNsSender(key, os.Getenv("APNS_KEY_ID"), os.Getenv("APNS_TEAM_ID"), os.Getenv("APNS_TOPIC"), os.Getenv("APNS_PRODUCTION") == "true")
+		if err != nil {
+			return nil, err
+		}
+		senders["ios"] = sender
+	}
+
+	if accountFile := os.Getenv("FCM_SERVICE_ACCOUNT_FILE"); accountFile != "" {
+		account, err := os.ReadFile(accountFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read FCM_SERVICE_ACCOUNT_FILE: %w", err)
+		}
+		sender, err := NewFCMSender(account)
+		if err != nil {
+			return nil, err
+		}
+		senders["android"] = sender
+	}
+
+	if len(senders) == 0 {
+		if os.Getenv("APP_ENV") == "production" {
+			log.Printf("Warning: No push provider is configured, reminders will not be delivered")
+			return NopNotifier{}, nil
+		}
+		return NewFakeNotifier(), nil
+	}
+	return NewPushNotifier(db, senders), nil
+}
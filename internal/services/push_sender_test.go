@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPNsSender(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	p8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	var gotPath, gotTopic, gotAuth string
+	var gotPayload map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotTopic = r.Header.Get("apns-topic")
+		gotAuth = r.Header.Get("authorization")
+		json.NewDecoder(r.Body).Decode(&gotPayload)
+		if r.URL.Path == "/3/device/bad-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"reason":"BadDeviceToken"}`))
+		}
+	}))
+	defer server.Close()
+
+	sender, err := NewAPNsSender(p8, "KEYID", "TEAMID", "com.example.app", false)
+	assert.NoError(t, err)
+	sender.baseURL = server.URL
+
+	goalID := uuid.New()
+	err = sender.Send(context.Background(), "device-token", Notification{GoalID: goalID, Title: "title", Body: "body"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/3/device/device-token", gotPath)
+	assert.Equal(t, "com.example.app", gotTopic)
+	assert.Contains(t, gotAuth, "bearer ")
+	assert.Equal(t, goalID.String(), gotPayload["goalId"])
+
+	err = sender.Send(context.Background(), "bad-token", Notification{})
+	assert.ErrorIs(t, err, ErrInvalidPushToken)
+}
+
+func TestFCMSender(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	tokenRequests := 0
+	var gotAuth string
+	var gotMessage map[string]map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			tokenRequests++
+			r.ParseForm()
+			assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.Form.Get("grant_type"))
+			w.Write([]byte(`{"access_token":"access-token","expires_in":3600}`))
+		case "/send":
+			gotAuth = r.Header.Get("Authorization")
+			json.NewDecoder(r.Body).Decode(&gotMessage)
+		}
+	}))
+	defer server.Close()
+
+	account, _ := json.Marshal(map[string]string{
+		"project_id":   "project",
+		"client_email": "sender@project.iam.gserviceaccount.com",
+		"private_key":  string(privateKey),
+		"token_uri":    server.URL + "/token",
+	})
+	sender, err := NewFCMSender(account)
+	assert.NoError(t, err)
+	sender.endpoint = server.URL + "/send"
+
+	for i := 0; i < 2; i++ {
+		err = sender.Send(context.Background(), "device-token", Notification{GoalID: uuid.New(), Title: "title", Body: "body"})
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, tokenRequests, "access token is cached")
+	assert.Equal(t, "Bearer access-token", gotAuth)
+	assert.Equal(t, "device-token", gotMessage["message"]["token"])
+}
+
+func TestNewNotifierFromEnv(t *testing.T) {
+	t.Setenv("APNS_KEY_FILE", "")
+	t.Setenv("FCM_SERVICE_ACCOUNT_FILE", "")
+
+	t.Run("Development", func(t *testing.T) {
+		t.Setenv("APP_ENV", "development")
+
+		notifier, err := NewNotifierFromEnv(nil)
+
+		assert.NoError(t, err)
+		assert.IsType(t, &FakeNotifier{}, notifier)
+	})
+
+	t.Run("Production", func(t *testing.T) {
+		t.Setenv("APP_ENV", "production")
+
+		notifier, err := NewNotifierFromEnv(nil)
+
+		assert.NoError(t, err)
+		assert.IsType(t, NopNotifier{}, notifier)
+	})
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"what-went-wrong-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const templateCandidateCount = 3
+
+// templateModel is the model name of generations picked from templates.
+const templateModel = "template"
+
+// TemplateAIService picks random active ExcuseTemplate rows.
+// It is the last resort of the fallback chain since it does not depend on external APIs.
+type TemplateAIService struct {
+	db *gorm.DB
+}
+
+func NewTemplateAIService(db *gorm.DB) *TemplateAIService {
+	return &TemplateAIService{db: db}
+}
+
+func (s *TemplateAIService) GenerateExcuse(req ExcuseGenerationRequest) (*GeneratedExcuses, error) {
+	var templates []models.ExcuseTemplate
+	if req.Tone != "" {
+		if err := s.pick(&templates, req.Tone); err != nil {
+			return nil, err
+		}
+	}
+	// Fall back to any tone so that the last resort still answers
+	if len(templates) == 0 {
+		if err := s.pick(&templates, ""); err != nil {
+			return nil, err
+		}
+	}
+	if len(templates) == 0 {
+		return nil, errors.New("no active excuse templates")
+	}
+
+	candidates := make([]string, len(templates))
+	for i, t := range templates {
+		candidates[i] = t.Text
+	}
+	return &GeneratedExcuses{Candidates: candidates, Model: templateModel}, nil
+}
+
+func (s *TemplateAIService) pick(templates *[]models.ExcuseTemplate, tone string) error {
+	query := s.db.Where("is_active = ?", true)
+	if tone != "" {
+		query = query.Where("? = ANY(tones)", tone)
+	}
+	return query.Order("random()").Limit(templateCandidateCount).Find(templates).Error
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // Register the PNG decoder for image.Decode
+)
+
+// Larger images are refused before decoding, since a few megabytes of PNG can decode to gigabytes
+const maxImagePixels = 50_000_000
+
+var ErrImageTooLarge = errors.New("image has too many pixels")
+
+// MakeThumbnail decodes a JPEG or PNG image and returns it as a JPEG whose longer side is at most maxSide pixels,
+// along with the width and height of the original. Smaller images keep their size; transparency becomes white.
+func MakeThumbnail(data []byte, maxSide int) (thumbnail []byte, width, height int, err error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, 0, 0, ErrImageTooLarge
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	b := src.Bounds()
+	width, height = b.Dx(), b.Dy()
+
+	tw, th := width, height
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			tw, th = maxSide, max(1, height*maxSide/width)
+		} else {
+			tw, th = max(1, width*maxSide/height), maxSide
+		}
+	}
+
+	// Each thumbnail pixel is the average of the source pixels it covers
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := b.Min.Y+y*height/th, b.Min.Y+max((y+1)*height/th, y*height/th+1)
+		for x := 0; x < tw; x++ {
+			x0, x1 := b.Min.X+x*width/tw, b.Min.X+max((x+1)*width/tw, x*width/tw+1)
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a, n = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca), n+1
+				}
+			}
+			// The colors are premultiplied, so adding the missing alpha lays the pixel over white
+			white := 0xffff - a/n
+			dst.Set(x, y, color.RGBA64{uint16(r/n + white), uint16(g/n + white), uint16(bl/n + white), 0xffff})
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, 0, 0, err
+	}
+	return buf.Bytes(), width, height, nil
+}
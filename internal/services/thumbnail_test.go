@@ -0,0 +1,76 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestMakeThumbnail(t *testing.T) {
+	t.Run("Downscale", func(t *testing.T) {
+		src := image.NewRGBA(image.Rect(0, 0, 800, 400))
+		for y := 0; y < 400; y++ {
+			for x := 0; x < 800; x++ {
+				src.Set(x, y, color.RGBA{200, 40, 40, 255})
+			}
+		}
+		thumb, width, height, err := MakeThumbnail(encodePNG(t, src), 320)
+		assert.NoError(t, err)
+		assert.Equal(t, 800, width)
+		assert.Equal(t, 400, height)
+
+		img, err := jpeg.Decode(bytes.NewReader(thumb))
+		assert.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 320, 160), img.Bounds())
+		r, g, _, _ := img.At(160, 80).RGBA()
+		assert.InDelta(t, 200, r>>8, 8)
+		assert.InDelta(t, 40, g>>8, 8)
+	})
+
+	t.Run("SmallImageKeepsSize", func(t *testing.T) {
+		thumb, _, _, err := MakeThumbnail(encodePNG(t, image.NewRGBA(image.Rect(0, 0, 100, 300))), 320)
+		assert.NoError(t, err)
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(thumb))
+		assert.NoError(t, err)
+		assert.Equal(t, 100, cfg.Width)
+		assert.Equal(t, 300, cfg.Height)
+	})
+
+	t.Run("TransparencyBecomesWhite", func(t *testing.T) {
+		thumb, _, _, err := MakeThumbnail(encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 10, 10))), 320)
+		assert.NoError(t, err)
+		img, _ := jpeg.Decode(bytes.NewReader(thumb))
+		r, g, b, _ := img.At(5, 5).RGBA()
+		assert.Greater(t, r>>8, uint32(245))
+		assert.Greater(t, g>>8, uint32(245))
+		assert.Greater(t, b>>8, uint32(245))
+	})
+
+	t.Run("TooManyPixels", func(t *testing.T) {
+		// Only the header is read, so the image data can be missing
+		header := encodePNG(t, image.NewGray(image.Rect(0, 0, 1, 1)))[:33]
+		header[16], header[17], header[18], header[19] = 0, 0, 0x27, 0x10 // width 10000
+		header[20], header[21], header[22], header[23] = 0, 0, 0x27, 0x10 // height 10000
+		binary.BigEndian.PutUint32(header[29:], crc32.ChecksumIEEE(header[12:29]))
+		_, _, _, err := MakeThumbnail(header, 320)
+		assert.ErrorIs(t, err, ErrImageTooLarge)
+	})
+
+	t.Run("NotAnImage", func(t *testing.T) {
+		_, _, _, err := MakeThumbnail([]byte("hello"), 320)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+	"what-went-wrong-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const defaultTrashRetentionDays = 30
+
+// TrashService permanently removes goals and excuses that have been in the trash longer than the retention period.
+type TrashService struct {
+	db        *gorm.DB
+	retention time.Duration
+	now       func() time.Time
+}
+
+func NewTrashService(db *gorm.DB, retention time.Duration) *TrashService {
+	return &TrashService{db: db, retention: retention, now: time.Now}
+}
+
+// NewTrashServiceFromEnv reads the retention in days from TRASH_RETENTION_DAYS (default 30).
+func NewTrashServiceFromEnv(db *gorm.DB) (*TrashService, error) {
+	days := defaultTrashRetentionDays
+	if v := os.Getenv("TRASH_RETENTION_DAYS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid TRASH_RETENTION_DAYS: %q", v)
+		}
+		days = n
+	}
+	return NewTrashService(db, time.Duration(days)*24*time.Hour), nil
+}
+
+// Retention returns how long items stay in the trash.
+func (s *TrashService) Retention() time.Duration {
+	return s.retention
+}
+
+// PurgeExpired permanently deletes the goals and excuses trashed before the retention period.
+// Excuses, pauses and the summary of a purged goal are removed with it.
+func (s *TrashService) PurgeExpired() error {
+	cutoff := s.now().Add(-s.retention)
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		expiredGoals := tx.Unscoped().Model(&models.Goal{}).Select("id").Where("deleted_at < ?", cutoff)
+		err := tx.Unscoped().
+			Where("deleted_at < ? OR goal_id IN (?)", cutoff, expiredGoals).
+			Delete(&models.ExcuseEntry{}).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("goal_id IN (?)", expiredGoals).Delete(&models.GoalSummary{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("goal_id IN (?)", expiredGoals).Delete(&models.PausePeriod{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("deleted_at < ?", cutoff).Delete(&models.Goal{}).Error
+	})
+}
+
+// Run purges the trash every hour until ctx is cancelled.
+func (s *TrashService) Run(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.PurgeExpired(); err != nil {
+				log.Printf("Trash purge failed: %v", err)
+			}
+		}
+	}
+}
@@ -1,8 +1,50 @@
 package services
 
+import "github.com/google/uuid"
+
 type Entitlements struct {
-	MaxGoals               int  `json:"maxGoals"`
-	LogRetentionDays       *int `json:"logRetentionDays"` // nil = unlimited
-	CanUseAiExcuse         bool `json:"canUseAiExcuse"`
-	CanUsePremiumTemplates bool `json:"canUsePremiumTemplates"`
+	MaxGoals               int   `json:"maxGoals"`
+	LogRetentionDays       *int  `json:"logRetentionDays"` // nil = unlimited
+	CanUseAiExcuse         bool  `json:"canUseAiExcuse"`
+	CanUsePremiumTemplates bool  `json:"canUsePremiumTemplates"`
+	CanUsePremiumPalettes  bool  `json:"canUsePremiumPalettes"`
+	MaxStorageBytes        int64 `json:"maxStorageBytes"` // Total size of the photos attached to excuses
+}
+
+type ExcuseGenerationRequest struct {
+	GoalID           uuid.UUID
+	GoalTitle        string
+	Tone             string // Tone ID
+	ToneInstructions string // Prompt instructions of the tone
+	Context          string
+}
+
+type GeneratedExcuses struct {
+	Candidates []string
+	Model      string // Identifier of the model that produced the candidates
+}
+
+type ModerationResult struct {
+	Text     string // Text after masking
+	Rejected bool
+	Flagged  bool     // Needs manual review
+	Reasons  []string // Matched rules / classifier categories
+}
+
+type Notification struct {
+	UserID string
+	GoalID uuid.UUID
+	Title  string
+	Body   string
+}
+
+// UserProfileUpdate holds the profile fields to change. Nil fields are left as they are.
+type UserProfileUpdate struct {
+	DisplayName                  *string
+	Locale                       *string
+	TimeZone                     *string
+	WeekStartDay                 *string
+	ReminderNotificationsEnabled *bool
+	NewsNotificationsEnabled     *bool
+	OnboardingCompleted          *bool
 }
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"what-went-wrong-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	// Embed the IANA database so time zones resolve on minimal container images
+	_ "time/tzdata"
+)
+
+const (
+	DefaultTimeZone = "Asia/Tokyo"
+	DefaultLocale   = "ja"
+)
+
+var ErrInvalidTimeZone = errors.New("invalid time zone")
+
+var defaultLocation = mustLoadLocation(DefaultTimeZone)
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// LoadLocation resolves an IANA time zone name, falling back to DefaultTimeZone when it is empty or unknown.
+func LoadLocation(name string) *time.Location {
+	if !IsValidTimeZone(name) {
+		return defaultLocation
+	}
+	loc, _ := time.LoadLocation(name)
+	return loc
+}
+
+// IsValidTimeZone reports whether name is an IANA time zone name.
+// "Local" and "" are rejected since they depend on the server.
+func IsValidTimeZone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// LocaleFromAcceptLanguage picks the supported locale (ja or en) for an Accept-Language header.
+// It returns "" when the header is empty.
+func LocaleFromAcceptLanguage(header string) string {
+	if header == "" {
+		return ""
+	}
+	if strings.HasPrefix(strings.ToLower(strings.TrimSpace(header)), "en") {
+		return "en"
+	}
+	return DefaultLocale
+}
+
+type UserService struct {
+	db *gorm.DB
+}
+
+func NewUserService(db *gorm.DB) *UserService {
+	return &UserService{db: db}
+}
+
+// GetOrCreateUser returns the user, creating it on the first request.
+// timeZone and locale (e.g. from request headers) are used for the new user when valid.
+func (s *UserService) GetOrCreateUser(userID string, timeZone string, locale string) (*models.User, error) {
+	var user models.User
+	if err := s.db.First(&user, "id = ?", userID).Error; err == nil {
+		return &user, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if !IsValidTimeZone(timeZone) {
+		timeZone = DefaultTimeZone
+	}
+	if locale != "en" {
+		locale = DefaultLocale
+	}
+	user = models.User{ID: userID, TimeZone: timeZone, Locale: locale}
+	// Concurrent first requests may race to create the same user
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&user).Error; err != nil {
+		return nil, err
+	}
+	if err := s.db.First(&user, "id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (s *UserService) UpdateTimeZone(userID string, timeZone string) (*models.User, error) {
+	if !IsValidTimeZone(timeZone) {
+		return nil, ErrInvalidTimeZone
+	}
+
+	return s.UpdateProfile(userID, UserProfileUpdate{TimeZone: &timeZone})
+}
+
+// UpdateProfile applies the non-nil fields of update to the user.
+func (s *UserService) UpdateProfile(userID string, update UserProfileUpdate) (*models.User, error) {
+	if update.TimeZone != nil && !IsValidTimeZone(*update.TimeZone) {
+		return nil, ErrInvalidTimeZone
+	}
+
+	user, err := s.GetOrCreateUser(userID, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	if update.DisplayName != nil {
+		user.DisplayName = *update.DisplayName
+	}
+	if update.Locale != nil {
+		user.Locale = *update.Locale
+	}
+	if update.TimeZone != nil {
+		user.TimeZone = *update.TimeZone
+	}
+	if update.WeekStartDay != nil {
+		user.WeekStartDay = *update.WeekStartDay
+	}
+	if update.ReminderNotificationsEnabled != nil {
+		user.ReminderNotificationsEnabled = *update.ReminderNotificationsEnabled
+	}
+	if update.NewsNotificationsEnabled != nil {
+		user.NewsNotificationsEnabled = *update.NewsNotificationsEnabled
+	}
+	if update.OnboardingCompleted != nil {
+		user.OnboardingCompleted = *update.OnboardingCompleted
+	}
+	user.UpdatedAt = time.Now()
+
+	if err := s.db.Save(user).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}